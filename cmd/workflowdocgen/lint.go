@@ -0,0 +1,119 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+
+	"github.com/huberp/github-workflow-doc/pkg/workflowdocgen"
+)
+
+// runLint runs the lint subcommand and returns the exit status: 0 if every
+// workflow passes, 1 if there are errors. Warnings do not fail the run.
+func runLint(args []string) int {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s lint [options]\n\nCheck the workflow annotations against the lint rules.\n\nOptions:\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	configFile := flags.String("config", "", "Path to the configuration file (default: "+workflowdocgen.ConfigFileName+" at the repository root)")
+	input := addInputFlags(flags)
+	customKeys := flags.String("custom-keys", "", "Comma-separated list of custom @workflow.* keys")
+	requiredFields := flags.String("required-fields", "description,owners", "Comma-separated list of annotations every workflow must have")
+	allowedTags := flags.String("allowed-tags", "", "Comma-separated list of allowed tags (default: any tag)")
+	ownerPattern := flags.String("owner-pattern", "", "Regular expression every owner handle must match")
+	maxDescriptionLength := flags.Int("max-description-length", 0, "Maximum number of characters of a description (default: no limit)")
+	nameMismatch := flags.Bool("name-mismatch", false, "Report an @workflow.name that differs from the declared name:")
+	verbose := flags.Bool("verbose", false, "Enable verbose logging")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	setupLogging(*verbose)
+
+	config, err := loadConfig(*configFile)
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		return 1
+	}
+
+	// Flags that are set explicitly override the configuration file
+	setFlags := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+	overrideList := func(name string, target *[]string, value string) {
+		if setFlags[name] || len(*target) == 0 {
+			*target = splitList(value)
+		}
+	}
+	input.apply(config, setFlags)
+	overrideList("custom-keys", &config.CustomKeys, *customKeys)
+	overrideList("required-fields", &config.RequiredFields, *requiredFields)
+	overrideList("allowed-tags", &config.Lint.AllowedTags, *allowedTags)
+	if setFlags["owner-pattern"] {
+		config.Lint.OwnerPattern = *ownerPattern
+	}
+	if setFlags["max-description-length"] {
+		config.Lint.MaxDescriptionLength = *maxDescriptionLength
+	}
+	if setFlags["name-mismatch"] {
+		config.Lint.NameMismatch = *nameMismatch
+	}
+
+	rules := workflowdocgen.LintRules{
+		RequiredFields:       config.RequiredFields,
+		AllowedTags:          config.Lint.AllowedTags,
+		MaxDescriptionLength: config.Lint.MaxDescriptionLength,
+		NameMismatch:         config.Lint.NameMismatch,
+	}
+	if config.Lint.OwnerPattern != "" {
+		rules.OwnerPattern, err = regexp.Compile(config.Lint.OwnerPattern)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid owner pattern: %v\n", err)
+			return 1
+		}
+	}
+
+	docs, err := loadWorkflows(config)
+	if err != nil {
+		slog.Error("Failed to load workflows", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	actions, err := loadActions(config)
+	if err != nil {
+		slog.Error("Failed to load actions", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	var diagnostics []workflowdocgen.Diagnostic
+	for _, doc := range docs {
+		diagnostics = append(diagnostics, workflowdocgen.Lint(doc, rules)...)
+	}
+	for _, action := range actions {
+		diagnostics = append(diagnostics, action.Diagnostics...)
+	}
+
+	errors, warnings := 0, 0
+	for _, diagnostic := range diagnostics {
+		fmt.Println(diagnostic)
+		if diagnostic.Severity == workflowdocgen.SeverityError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+
+	if errors > 0 || warnings > 0 {
+		fmt.Fprintf(os.Stderr, "Found %d error(s) and %d warning(s) in %d workflow(s) and %d action(s)\n", errors, warnings, len(docs), len(actions))
+	}
+	if errors > 0 {
+		return 1
+	}
+	slog.Info("Lint complete", "workflows", len(docs))
+	return 0
+}
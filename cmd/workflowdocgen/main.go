@@ -1,72 +1,205 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/huberp/github-workflow-doc/pkg/workflowdocgen"
 )
 
+// Directories read when none are configured. With a configuration file they
+// are relative to its directory, otherwise to the working directory.
+const (
+	defaultWorkflowsDir = ".github/workflows"
+	defaultActionsDir   = ".github/actions"
+)
+
 func main() {
+	// Subcommands are dispatched before the flags of the default command are parsed
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		os.Exit(runLint(os.Args[2:]))
+	}
+
 	// Define flags
-	workflowsDir := flag.String("workflows-dir", ".github/workflows", "Path to the workflows directory")
-	outputFile := flag.String("output", "WORKFLOWS.md", "Path to the output markdown file")
+	configFile := flag.String("config", "", "Path to the configuration file (default: "+workflowdocgen.ConfigFileName+" at the repository root)")
+	input := addInputFlags(flag.CommandLine)
+	outputFile := flag.String("output", "WORKFLOWS.md", "Path to the output file, or - for stdout")
+	format := flag.String("format", "markdown", "Output format: "+strings.Join(workflowdocgen.Formats(), ", "))
+	customKeys := flag.String("custom-keys", "", "Comma-separated list of custom @workflow.* keys to render in the detail section")
+	templateFile := flag.String("template", "", "Path to a Go text/template file that replaces the default markdown layout")
+	sortOrder := flag.String("sort", workflowdocgen.SortByFile, "Sort order of the workflows: "+strings.Join(workflowdocgen.SortOrders, ", "))
+	inject := flag.Bool("inject", false, "Replace only the section between the workflowdocgen:start and workflowdocgen:end markers of the output file")
+	check := flag.Bool("check", false, "Check that the output file is up to date instead of writing it; prints a diff and exits with status 1 if not")
 	verbose := flag.Bool("verbose", false, "Enable verbose logging")
 	flag.Parse()
 
-	// Setup structured logging
-	logLevel := slog.LevelWarn
-	if *verbose {
-		logLevel = slog.LevelInfo
-	}
-	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-		Level: logLevel,
-	}))
-	slog.SetDefault(logger)
-
-	slog.Info("Starting workflow documentation generation", "workflows-dir", *workflowsDir, "output", *outputFile)
+	setupLogging(*verbose)
 
-	// Check if workflows directory exists
-	if _, err := os.Stat(*workflowsDir); os.IsNotExist(err) {
-		slog.Error("Workflows directory does not exist", "path", *workflowsDir)
-		fmt.Fprintf(os.Stderr, "Error: Workflows directory does not exist: %s\n", *workflowsDir)
+	config, err := loadConfig(*configFile)
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
 	}
 
-	slog.Info("Parsing workflow files", "directory", *workflowsDir)
+	// Flags that are set explicitly override the configuration file
+	setFlags := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+	override := func(name string, target *string, value string) {
+		if setFlags[name] || *target == "" {
+			*target = value
+		}
+	}
+	input.apply(config, setFlags)
+	if setFlags["custom-keys"] || len(config.CustomKeys) == 0 {
+		config.CustomKeys = splitList(*customKeys)
+	}
+	if !setFlags["output"] && config.Output == "" {
+		// The default output, like the default directories, belongs at the
+		// root of a repository with a configuration file
+		*outputFile = config.Resolve(*outputFile)
+	}
+	override("output", &config.Output, *outputFile)
+	override("format", &config.Format, *format)
+	override("template", &config.Template, *templateFile)
+	override("sort", &config.Sort, *sortOrder)
 
-	// Parse all workflow files
-	docs, err := workflowdocgen.ParseWorkflowsDirectory(*workflowsDir)
+	slog.Info("Starting workflow documentation generation", "workflows-dirs", config.WorkflowsDirs, "output", config.Output)
+
+	docs, err := loadWorkflows(config)
 	if err != nil {
-		slog.Error("Failed to parse workflows", "error", err)
-		fmt.Fprintf(os.Stderr, "Error parsing workflows: %v\n", err)
+		slog.Error("Failed to load workflows", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
 	slog.Info("Parsed workflows", "count", len(docs))
 
 	if len(docs) == 0 {
-		slog.Warn("No workflow files found", "directory", *workflowsDir)
-		fmt.Fprintf(os.Stderr, "Warning: No workflow files found in %s\n", *workflowsDir)
+		slog.Warn("No workflow files found", "directories", config.WorkflowsDirs)
+		fmt.Fprintf(os.Stderr, "Warning: No workflow files found in %s\n", strings.Join(config.WorkflowsDirs, ", "))
 	}
 
-	// Generate markdown table
-	absOutputPath, err := filepath.Abs(*outputFile)
+	for _, doc := range docs {
+		for _, diagnostic := range doc.Diagnostics {
+			fmt.Fprintln(os.Stderr, diagnostic)
+		}
+		for _, field := range doc.MissingFields(config.RequiredFields) {
+			slog.Warn("Workflow is missing a required field", "file", doc.FilePath, "field", field)
+		}
+	}
+
+	if err := workflowdocgen.ValidateColumns(config.Columns); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	actions, err := loadActions(config)
+	if err != nil {
+		slog.Error("Failed to load actions", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	for _, action := range actions {
+		for _, diagnostic := range action.Diagnostics {
+			fmt.Fprintln(os.Stderr, diagnostic)
+		}
+	}
+
+	markdownOptions := workflowdocgen.RenderOptions{
+		CustomKeys: config.CustomKeys,
+		Columns:    config.Columns,
+		Actions:    actions,
+	}
+
+	if config.Template != "" {
+		if config.Format != "markdown" {
+			fmt.Fprintf(os.Stderr, "Error: --template is only supported with --format markdown\n")
+			os.Exit(1)
+		}
+		text, err := os.ReadFile(config.Template) // #nosec G304 - template path is provided by the user
+		if err != nil {
+			slog.Error("Failed to read template", "error", err)
+			fmt.Fprintf(os.Stderr, "Error reading template: %v\n", err)
+			os.Exit(1)
+		}
+		markdownOptions.Template, err = workflowdocgen.ParseMarkdownTemplate(string(text))
+		if err != nil {
+			slog.Error("Failed to parse template", "error", err)
+			fmt.Fprintf(os.Stderr, "Error parsing template: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	renderer, err := workflowdocgen.NewRenderer(config.Format, markdownOptions)
+	if err != nil {
+		slog.Error("Invalid output format", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	slog.Info("Rendering documentation", "format", config.Format)
+
+	var rendered bytes.Buffer
+	if err := renderer.Render(&rendered, docs); err != nil {
+		slog.Error("Failed to render documentation", "error", err)
+		fmt.Fprintf(os.Stderr, "Error rendering documentation: %v\n", err)
+		os.Exit(1)
+	}
+
+	if config.Output == "-" {
+		if *check || *inject {
+			fmt.Fprintln(os.Stderr, "Error: --check and --inject need an output file, not stdout")
+			os.Exit(1)
+		}
+		if _, err := rendered.WriteTo(os.Stdout); err != nil {
+			slog.Error("Failed to write documentation", "error", err)
+			fmt.Fprintf(os.Stderr, "Error writing documentation: %v\n", err)
+			os.Exit(1)
+		}
+		slog.Info("Documentation generation complete", "output", "stdout", "workflows", len(docs))
+		return
+	}
+
+	absOutputPath, err := filepath.Abs(config.Output)
 	if err != nil {
 		slog.Error("Failed to resolve output path", "error", err)
 		fmt.Fprintf(os.Stderr, "Error resolving output path: %v\n", err)
 		os.Exit(1)
 	}
 
-	slog.Info("Generating markdown documentation", "output", absOutputPath)
+	content := rendered.String()
+	if *inject {
+		existing, err := os.ReadFile(absOutputPath) // #nosec G304 - output path is provided by the user
+		if err != nil {
+			slog.Error("Failed to read output file", "error", err)
+			fmt.Fprintf(os.Stderr, "Error reading output file: %v\n", err)
+			os.Exit(1)
+		}
+		content, err = workflowdocgen.InjectBetweenMarkers(string(existing), content)
+		if err != nil {
+			slog.Error("Failed to inject documentation", "output", absOutputPath, "error", err)
+			fmt.Fprintf(os.Stderr, "Error injecting documentation into %s: %v\n", config.Output, err)
+			os.Exit(1)
+		}
+	}
 
-	err = workflowdocgen.GenerateMarkdownTable(docs, absOutputPath)
-	if err != nil {
-		slog.Error("Failed to generate markdown", "error", err)
-		fmt.Fprintf(os.Stderr, "Error generating markdown: %v\n", err)
+	if *check {
+		os.Exit(checkOutput(content, config.Output, absOutputPath))
+	}
+
+	slog.Info("Writing documentation", "output", absOutputPath)
+
+	// Write to file with readable permissions for collaborative environments
+	// #nosec G306 - 0644 is intentional for collaborative environments
+	if err := os.WriteFile(absOutputPath, []byte(content), 0644); err != nil {
+		slog.Error("Failed to write documentation", "error", err)
+		fmt.Fprintf(os.Stderr, "Error writing documentation: %v\n", err)
 		os.Exit(1)
 	}
 
@@ -74,3 +207,192 @@ func main() {
 	fmt.Printf("Successfully generated workflow documentation at %s\n", absOutputPath)
 	fmt.Printf("Documented %d workflow(s)\n", len(docs))
 }
+
+// setupLogging installs the structured logger, at info level when verbose
+func setupLogging(verbose bool) {
+	logLevel := slog.LevelWarn
+	if verbose {
+		logLevel = slog.LevelInfo
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: logLevel,
+	}))
+	slog.SetDefault(logger)
+}
+
+// stringList is a flag that can be repeated, collecting every value
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ", ")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// inputFlags are the flags that select the workflow files to read
+type inputFlags struct {
+	workflowsDirs stringList
+	actionsDirs   stringList
+	recursive     *bool
+	include       *string
+	exclude       *string
+}
+
+// addInputFlags defines the input flags on flags
+func addInputFlags(flags *flag.FlagSet) *inputFlags {
+	input := &inputFlags{}
+	flags.Var(&input.workflowsDirs, "workflows-dir", "Path to a workflows directory; may be repeated (default: .github/workflows)")
+	flags.Var(&input.actionsDirs, "actions-dir", "Path to a directory with action.yml files in its subdirectories; may be repeated (default: .github/actions)")
+	input.recursive = flags.Bool("recursive", false, "Also read workflow files in subdirectories of the workflows directories")
+	input.include = flags.String("include", "", "Comma-separated glob patterns of the workflow files to read, e.g. deploy-*.yml or templates/**/*.yml")
+	input.exclude = flags.String("exclude", "", "Comma-separated glob patterns of the workflow files to skip")
+	return input
+}
+
+// apply overrides the configuration with the input flags that are set
+func (f *inputFlags) apply(config *workflowdocgen.Config, setFlags map[string]bool) {
+	if len(f.workflowsDirs) > 0 {
+		config.WorkflowsDirs = f.workflowsDirs
+	}
+	if len(config.WorkflowsDirs) == 0 {
+		config.WorkflowsDirs = []string{config.Resolve(defaultWorkflowsDir)}
+	}
+	if len(f.actionsDirs) > 0 {
+		config.ActionsDirs = f.actionsDirs
+	}
+	if setFlags["recursive"] {
+		config.Recursive = *f.recursive
+	}
+	if setFlags["include"] {
+		config.Include = splitList(*f.include)
+	}
+	if setFlags["exclude"] {
+		config.Exclude = splitList(*f.exclude)
+	}
+}
+
+// loadWorkflows parses the workflows of every configured directory and
+// returns the selected ones in the configured order
+func loadWorkflows(config *workflowdocgen.Config) ([]*workflowdocgen.WorkflowDoc, error) {
+	for _, dir := range config.WorkflowsDirs {
+		// Check if workflows directory exists
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			return nil, fmt.Errorf("workflows directory does not exist: %s", dir)
+		}
+	}
+
+	slog.Info("Parsing workflow files", "directories", config.WorkflowsDirs, "recursive", config.Recursive)
+
+	docs, err := workflowdocgen.ParseWorkflowsDirectories(config.WorkflowsDirs, workflowdocgen.ParseOptions{
+		CustomKeys: config.CustomKeys,
+		Recursive:  config.Recursive,
+		Include:    config.Include,
+		Exclude:    config.Exclude,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parsing workflows: %w", err)
+	}
+
+	if err := workflowdocgen.SortWorkflows(docs, config.Sort); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// loadActions parses the actions of every configured actions directory, or
+// of .github/actions if it exists and none are configured
+func loadActions(config *workflowdocgen.Config) ([]*workflowdocgen.ActionDoc, error) {
+	dirs := config.ActionsDirs
+	if len(dirs) == 0 {
+		dirs = []string{config.Resolve(defaultActionsDir)}
+	}
+
+	var actions []*workflowdocgen.ActionDoc
+	for _, dir := range dirs {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			if len(config.ActionsDirs) == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("actions directory does not exist: %s", dir)
+		}
+
+		slog.Info("Parsing action files", "directory", dir)
+
+		dirActions, err := workflowdocgen.ParseActionsDirectory(dir, workflowdocgen.ParseOptions{
+			CustomKeys: config.CustomKeys,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("parsing actions: %w", err)
+		}
+		actions = append(actions, dirActions...)
+	}
+
+	// Workflows refer to local actions relative to the repository root: the
+	// directory of the configuration file, or else the working directory
+	root, err := filepath.Abs(config.Resolve("."))
+	if err != nil {
+		return actions, nil
+	}
+	for _, action := range actions {
+		dir, err := filepath.Abs(action.Dir)
+		if err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(root, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			action.Dir = rel
+		}
+	}
+	return actions, nil
+}
+
+// loadConfig loads the given configuration file, or the one at the repository
+// root if path is empty. Without a configuration file, it returns an empty one.
+func loadConfig(path string) (*workflowdocgen.Config, error) {
+	if path == "" {
+		found, err := workflowdocgen.FindConfig(".")
+		if err != nil || found == "" {
+			return &workflowdocgen.Config{}, err
+		}
+		path = found
+	}
+
+	slog.Info("Loading configuration", "path", path)
+	return workflowdocgen.LoadConfig(path)
+}
+
+// checkOutput compares the rendered documentation with the existing output file
+// and returns the exit status: 0 if it is up to date, 1 if it is stale or missing
+func checkOutput(rendered, name, path string) int {
+	slog.Info("Checking documentation", "output", path)
+
+	existing, err := os.ReadFile(path) // #nosec G304 - output path is provided by the user
+	if err != nil && !os.IsNotExist(err) {
+		slog.Error("Failed to read output file", "error", err)
+		fmt.Fprintf(os.Stderr, "Error reading output file: %v\n", err)
+		return 1
+	}
+
+	diff := workflowdocgen.UnifiedDiff(name, name+" (generated)", string(existing), rendered)
+	if diff == "" {
+		fmt.Printf("%s is up to date\n", name)
+		return 0
+	}
+
+	fmt.Print(diff)
+	fmt.Fprintf(os.Stderr, "Error: %s is out of date; run workflowdocgen to regenerate it\n", name)
+	return 1
+}
+
+// splitList splits a comma-separated flag value into trimmed, non-empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
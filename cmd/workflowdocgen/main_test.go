@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFromSubdirectory(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".workflowdocgen.yml":               "sort: name\n",
+		".github/workflows/ci.yml":          "name: CI\non: push\n",
+		".github/actions/setup/action.yml":  "name: Setup\nruns:\n  using: composite\n  steps: []\n",
+		"services/api/templates/.gitignore": "",
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0750); err != nil { // #nosec G301 - test directory
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil { // #nosec G301 - test directory
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil { // #nosec G306 - test file
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	t.Chdir(filepath.Join(root, "services", "api"))
+
+	config, err := loadConfig("")
+	if err != nil {
+		t.Fatalf("loadConfig failed: %v", err)
+	}
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	input := addInputFlags(flags)
+	if err := flags.Parse(nil); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	input.apply(config, map[string]bool{})
+
+	docs, err := loadWorkflows(config)
+	if err != nil {
+		t.Fatalf("loadWorkflows failed: %v", err)
+	}
+	if len(docs) != 1 || docs[0].FileName != "ci.yml" {
+		t.Errorf("Expected the workflow at the repository root, got %d workflow(s)", len(docs))
+	}
+
+	actions, err := loadActions(config)
+	if err != nil {
+		t.Fatalf("loadActions failed: %v", err)
+	}
+	if len(actions) != 1 || actions[0].Reference() != "./.github/actions/setup" {
+		t.Errorf("Expected the action at the repository root, got %d action(s)", len(actions))
+	}
+}
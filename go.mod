@@ -1,3 +1,5 @@
 module github.com/huberp/github-workflow-doc
 
 go 1.25.3
+
+require gopkg.in/yaml.v3 v3.0.1
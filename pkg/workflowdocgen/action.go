@@ -0,0 +1,321 @@
+package workflowdocgen
+
+import (
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ActionDoc represents the documentation for a local action defined by an
+// action.yml file
+type ActionDoc struct {
+	// Name is the @action.name annotation; see DisplayName for the fallback
+	Name         string
+	DeclaredName string
+	// Description is the @action.description annotation, or the declared
+	// description when there is none
+	Description  string
+	Author       string
+	Owners       string
+	Tags         string
+	OwnerList    []string
+	TagList      []string
+	Requirements string
+	// Using is the runs.using value, e.g. composite, docker or node20
+	Using string
+	// Main is the entry point of a JavaScript action and Image the image of
+	// a Docker action
+	Main     string
+	Image    string
+	Inputs   []ActionInput
+	Outputs  []Output
+	Branding *Branding
+	FilePath string
+	// Dir is the directory of the action, which other workflows refer to
+	Dir string
+	// Extra holds @action.* annotations that are not built-in fields
+	Extra map[string]string
+	// Positions holds where each @action.* annotation starts, by key
+	Positions map[string]Position
+	// Diagnostics holds problems with the annotations found while parsing
+	Diagnostics []Diagnostic
+}
+
+// ActionInput represents an input declared under inputs of an action
+type ActionInput struct {
+	Name               string
+	Description        string
+	Required           bool
+	Default            string
+	DeprecationMessage string
+}
+
+// Branding is the icon and colour of an action on the GitHub Marketplace
+type Branding struct {
+	Icon  string
+	Color string
+}
+
+// actionFileNames are the file names of action metadata files
+var actionFileNames = []string{"action.yml", "action.yaml"}
+
+// Annotation kinds of action files, and the built-in fields of action annotations
+var (
+	actionKinds  = []string{"action", "param", "output"}
+	actionFields = []string{"name", "description", "owners", "tags", "requirements"}
+)
+
+// DisplayName returns the annotated name, falling back to the declared name
+// and then to the name of the action directory
+func (a *ActionDoc) DisplayName() string {
+	switch {
+	case a.Name != "":
+		return a.Name
+	case a.DeclaredName != "":
+		return a.DeclaredName
+	}
+	return filepath.Base(a.Dir)
+}
+
+// OwnerHandles returns the normalised owners of the action
+func (a *ActionDoc) OwnerHandles() []string {
+	return listOrParse(a.OwnerList, a.Owners, parseOwners)
+}
+
+// TagNames returns the normalised tags of the action
+func (a *ActionDoc) TagNames() []string {
+	return listOrParse(a.TagList, a.Tags, parseList)
+}
+
+// Reference returns the value of uses: that runs the action from a workflow
+// of the same repository
+func (a *ActionDoc) Reference() string {
+	dir := filepath.ToSlash(a.Dir)
+	if filepath.IsAbs(a.Dir) || strings.HasPrefix(dir, "./") {
+		return dir
+	}
+	return "./" + dir
+}
+
+// UsageSnippet returns a YAML step that runs the action, with a with: entry
+// per input that is not deprecated
+func (a *ActionDoc) UsageSnippet() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("- uses: %s\n", a.Reference()))
+
+	var inputs []ActionInput
+	for _, input := range a.Inputs {
+		if input.DeprecationMessage == "" {
+			inputs = append(inputs, input)
+		}
+	}
+
+	if len(inputs) > 0 {
+		sb.WriteString("  with:\n")
+		for _, input := range inputs {
+			sb.WriteString(fmt.Sprintf("    %s: %s # %s\n", input.Name, usageValue(input.Default), usageComment(input.Required, "")))
+		}
+	}
+
+	return sb.String()
+}
+
+// ParseActionFile parses an action.yml file and extracts documentation comments
+func ParseActionFile(filePath string) (*ActionDoc, error) {
+	return ParseActionFileWithOptions(filePath, ParseOptions{})
+}
+
+// ParseActionFileWithOptions parses an action.yml file using the given options
+func ParseActionFileWithOptions(filePath string, opts ParseOptions) (*ActionDoc, error) {
+	content, err := os.ReadFile(filepath.Clean(filePath)) // #nosec G304 - action files are found in the configured directories
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &ActionDoc{
+		FilePath: filePath,
+		Dir:      filepath.Dir(filePath),
+	}
+
+	scanned, err := annotationScanner{
+		file:       filePath,
+		kinds:      actionKinds,
+		fields:     actionFields,
+		customKeys: opts.CustomKeys,
+		noteKinds:  []string{"param", "output"},
+	}.scan(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := parseActionYAML(doc, content); err != nil {
+		// Notes cannot be matched to the inputs and outputs of a broken file
+		scanned.diagnostics = append(scanned.diagnostics, yamlDiagnostic(filePath, err))
+	} else {
+		scanned.checkNotes(filePath, doc.declares)
+	}
+	doc.Diagnostics = scanned.diagnostics
+	sortDiagnostics(doc.Diagnostics)
+
+	// Annotations override the declared values
+	for _, a := range scanned.fields {
+		doc.set(a.field, a.value)
+	}
+	doc.Extra = scanned.extra
+	doc.Positions = scanned.positions
+
+	for i := range doc.Inputs {
+		doc.Inputs[i].Description = mergeDescription(doc.Inputs[i].Description, scanned.notes["param."+doc.Inputs[i].Name])
+	}
+	for i := range doc.Outputs {
+		doc.Outputs[i].Description = mergeDescription(doc.Outputs[i].Description, scanned.notes["output."+doc.Outputs[i].Name])
+	}
+
+	return doc, nil
+}
+
+// set assigns an action annotation field, ignoring unknown fields
+func (a *ActionDoc) set(field, value string) {
+	switch field {
+	case "name":
+		a.Name = value
+	case "description":
+		a.Description = value
+	case "owners":
+		a.Owners = value
+		a.OwnerList = parseOwners(value)
+	case "tags":
+		a.Tags = value
+		a.TagList = parseList(value)
+	case "requirements":
+		a.Requirements = value
+	}
+}
+
+// declares reports whether the action declares the input or output that a
+// note of the given kind refers to
+func (a *ActionDoc) declares(kind, name string) bool {
+	switch kind {
+	case "param":
+		return slices.ContainsFunc(a.Inputs, func(i ActionInput) bool { return i.Name == name })
+	case "output":
+		return slices.ContainsFunc(a.Outputs, func(o Output) bool { return o.Name == name })
+	}
+	return false
+}
+
+// parseActionYAML decodes the action metadata and populates doc from it.
+// Annotations parsed afterwards override the declared values.
+func parseActionYAML(doc *ActionDoc, content []byte) error {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return err
+	}
+
+	root := documentRoot(&document)
+	if root == nil {
+		return nil
+	}
+
+	doc.DeclaredName = scalarValue(mappingValue(root, "name"))
+	doc.Description = strings.TrimSpace(scalarValue(mappingValue(root, "description")))
+	doc.Author = scalarValue(mappingValue(root, "author"))
+
+	if inputs := mappingValue(root, "inputs"); inputs != nil && inputs.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(inputs.Content); i += 2 {
+			config := inputs.Content[i+1]
+			doc.Inputs = append(doc.Inputs, ActionInput{
+				Name:               inputs.Content[i].Value,
+				Description:        scalarValue(mappingValue(config, "description")),
+				Required:           scalarValue(mappingValue(config, "required")) == "true",
+				Default:            scalarValue(mappingValue(config, "default")),
+				DeprecationMessage: scalarValue(mappingValue(config, "deprecationMessage")),
+			})
+		}
+	}
+
+	if outputs := mappingValue(root, "outputs"); outputs != nil && outputs.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(outputs.Content); i += 2 {
+			config := outputs.Content[i+1]
+			doc.Outputs = append(doc.Outputs, Output{
+				Name:        outputs.Content[i].Value,
+				Description: scalarValue(mappingValue(config, "description")),
+				Value:       scalarValue(mappingValue(config, "value")),
+			})
+		}
+	}
+
+	if runs := mappingValue(root, "runs"); runs != nil {
+		doc.Using = scalarValue(mappingValue(runs, "using"))
+		doc.Main = scalarValue(mappingValue(runs, "main"))
+		doc.Image = scalarValue(mappingValue(runs, "image"))
+	}
+
+	if branding := mappingValue(root, "branding"); branding != nil {
+		doc.Branding = &Branding{
+			Icon:  scalarValue(mappingValue(branding, "icon")),
+			Color: scalarValue(mappingValue(branding, "color")),
+		}
+	}
+
+	return nil
+}
+
+// ParseActionsDirectory parses the action.yml files in a directory and its
+// subdirectories, e.g. .github/actions. Actions are returned in lexical order
+// of their path; a missing directory has no actions.
+func ParseActionsDirectory(dirPath string, opts ParseOptions) ([]*ActionDoc, error) {
+	var docs []*ActionDoc
+
+	cleanDirPath := filepath.Clean(dirPath)
+	root, err := walkRoot(cleanDirPath)
+	if err != nil || root == "" {
+		return nil, err
+	}
+
+	err = filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if entry.Name() == ".git" || entry.Name() == "node_modules" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !slices.Contains(actionFileNames, entry.Name()) {
+			return nil
+		}
+		if entry.Type()&os.ModeSymlink != 0 {
+			slog.Warn("Skipping symlink", "file", file)
+			return nil
+		}
+
+		// Actions are documented below dirPath, not below the resolved root
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		file = filepath.Join(cleanDirPath, rel)
+
+		doc, err := ParseActionFileWithOptions(file, opts)
+		if err != nil {
+			slog.Warn("Failed to parse action file", "file", file, "error", err)
+			return nil
+		}
+		docs = append(docs, doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return docs, nil
+}
@@ -0,0 +1,288 @@
+package workflowdocgen
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const setupGoAction = `# @action.description: Installs Go.
+#   Restores the module cache when enabled.
+# @action.owners: @Acme/Platform
+# @action.tags: Go, setup
+# @action.runbook: docs/setup-go.md
+# @param.cache: Speeds up repeated builds.
+name: Setup Go
+description: Install Go
+author: Platform team
+inputs:
+  go-version:
+    description: Go version to install
+    required: true
+  cache:
+    description: Whether to cache modules
+    default: "true"
+  legacy:
+    description: Old switch
+    deprecationMessage: Use cache instead
+outputs:
+  go-path:
+    description: Path of the Go binary
+    value: ${{ steps.setup.outputs.path }}
+runs:
+  using: composite
+  steps:
+    - run: echo
+      shell: bash
+branding:
+  icon: package
+  color: blue
+`
+
+func TestParseActionFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	t.Run("composite action with annotations", func(t *testing.T) {
+		filePath := writeTestFile(t, tempDir, "setup-go/action.yml", setupGoAction)
+		doc, err := ParseActionFileWithOptions(filePath, ParseOptions{CustomKeys: []string{"runbook"}})
+		if err != nil {
+			t.Fatalf("ParseActionFileWithOptions failed: %v", err)
+		}
+
+		if doc.DisplayName() != "Setup Go" {
+			t.Errorf("Expected name 'Setup Go', got '%s'", doc.DisplayName())
+		}
+		if doc.Description != "Installs Go.\nRestores the module cache when enabled." {
+			t.Errorf("Expected the annotated description, got '%s'", doc.Description)
+		}
+		if doc.Author != "Platform team" || doc.Using != "composite" {
+			t.Errorf("Expected author and runs.using, got '%s' and '%s'", doc.Author, doc.Using)
+		}
+		if !slices.Equal(doc.OwnerHandles(), []string{"acme/platform"}) || !slices.Equal(doc.TagNames(), []string{"go", "setup"}) {
+			t.Errorf("Expected owners and tags, got %v and %v", doc.OwnerHandles(), doc.TagNames())
+		}
+		if doc.Extra["runbook"] != "docs/setup-go.md" {
+			t.Errorf("Expected runbook in Extra, got %v", doc.Extra)
+		}
+		if doc.Branding == nil || doc.Branding.Icon != "package" || doc.Branding.Color != "blue" {
+			t.Errorf("Expected branding, got %+v", doc.Branding)
+		}
+		if doc.Dir != filepath.Join(tempDir, "setup-go") {
+			t.Errorf("Expected the directory of the action, got '%s'", doc.Dir)
+		}
+
+		expectedInputs := []ActionInput{
+			{Name: "go-version", Description: "Go version to install", Required: true},
+			{Name: "cache", Description: "Whether to cache modules. Speeds up repeated builds.", Default: "true"},
+			{Name: "legacy", Description: "Old switch", DeprecationMessage: "Use cache instead"},
+		}
+		if !slices.Equal(doc.Inputs, expectedInputs) {
+			t.Errorf("Expected inputs %+v, got %+v", expectedInputs, doc.Inputs)
+		}
+		expectedOutputs := []Output{{Name: "go-path", Description: "Path of the Go binary", Value: "${{ steps.setup.outputs.path }}"}}
+		if !slices.Equal(doc.Outputs, expectedOutputs) {
+			t.Errorf("Expected outputs %+v, got %+v", expectedOutputs, doc.Outputs)
+		}
+		if len(doc.Diagnostics) != 0 {
+			t.Errorf("Expected no diagnostics, got %v", doc.Diagnostics)
+		}
+	})
+
+	t.Run("docker action without annotations", func(t *testing.T) {
+		filePath := writeTestFile(t, tempDir, "lint/action.yaml", `# @Action.description: Lint
+# @action.name: Lint
+# @action.name: Docker lint
+runs:
+  using: docker
+  image: Dockerfile
+`)
+		doc, err := ParseActionFile(filePath)
+		if err != nil {
+			t.Fatalf("ParseActionFile failed: %v", err)
+		}
+
+		if doc.DisplayName() != "Docker lint" || doc.Image != "Dockerfile" || doc.Description != "" {
+			t.Errorf("Unexpected action %+v", doc)
+		}
+
+		var got []string
+		for _, d := range doc.Diagnostics {
+			got = append(got, d.String())
+		}
+		expected := []string{
+			filePath + ":1:1: warning: annotation keys are lowercase, use @action.description instead of @Action.description",
+			filePath + ":3:1: warning: duplicate annotation @action.name overrides the one on line 2",
+		}
+		if !slices.Equal(got, expected) {
+			t.Errorf("Expected diagnostics %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("notes for undeclared inputs and outputs", func(t *testing.T) {
+		filePath := writeTestFile(t, tempDir, "notes/action.yml", `# @param.nope: First
+# @param.nope: Second
+# @output.path: Installed path
+name: Notes
+outputs:
+  path:
+    value: /usr/local
+`)
+		doc, err := ParseActionFile(filePath)
+		if err != nil {
+			t.Fatalf("ParseActionFile failed: %v", err)
+		}
+
+		var got []string
+		for _, d := range doc.Diagnostics {
+			got = append(got, d.String())
+		}
+		expected := []string{
+			filePath + ":2:1: warning: duplicate annotation @param.nope overrides the one on line 1",
+			filePath + ":2:1: warning: @param.nope does not match any declared input",
+		}
+		if !slices.Equal(got, expected) {
+			t.Errorf("Expected diagnostics %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("invalid YAML", func(t *testing.T) {
+		filePath := writeTestFile(t, tempDir, "broken/action.yml", "# @param.nope: Undeclared\n# @param.nope: Twice\nname: [broken\n")
+		doc, err := ParseActionFile(filePath)
+		if err != nil {
+			t.Fatalf("ParseActionFile failed: %v", err)
+		}
+
+		var rules []string
+		for _, d := range doc.Diagnostics {
+			rules = append(rules, d.Rule)
+		}
+		if !slices.Equal(rules, []string{RuleDuplicateAnnotation, RuleInvalidYAML}) {
+			t.Errorf("Expected a duplicate and an invalid YAML diagnostic, got %v", doc.Diagnostics)
+		}
+		if last := doc.Diagnostics[len(doc.Diagnostics)-1]; last.Severity != SeverityError {
+			t.Errorf("Expected invalid YAML to be an error, got %s", last.Severity)
+		}
+	})
+
+	t.Run("display name falls back to the directory", func(t *testing.T) {
+		doc := &ActionDoc{Dir: ".github/actions/cache"}
+		if doc.DisplayName() != "cache" {
+			t.Errorf("Expected 'cache', got '%s'", doc.DisplayName())
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := ParseActionFile(filepath.Join(tempDir, "missing", "action.yml")); err == nil {
+			t.Error("Expected error for missing file, got nil")
+		}
+	})
+}
+
+func TestActionDocUsage(t *testing.T) {
+	doc := &ActionDoc{
+		Dir: filepath.Join(".github", "actions", "setup-go"),
+		Inputs: []ActionInput{
+			{Name: "go-version", Required: true},
+			{Name: "cache", Default: "true"},
+			{Name: "legacy", DeprecationMessage: "Use cache instead"},
+		},
+	}
+
+	if doc.Reference() != "./.github/actions/setup-go" {
+		t.Errorf("Expected './.github/actions/setup-go', got '%s'", doc.Reference())
+	}
+
+	expected := `- uses: ./.github/actions/setup-go
+  with:
+    go-version: "" # required
+    cache: true # optional
+`
+	if got := doc.UsageSnippet(); got != expected {
+		t.Errorf("Expected usage:\n%s\ngot:\n%s", expected, got)
+	}
+
+	if got := (&ActionDoc{Dir: "./actions/x"}).UsageSnippet(); got != "- uses: ./actions/x\n" {
+		t.Errorf("Expected a step without inputs, got '%s'", got)
+	}
+}
+
+func TestParseActionsDirectory(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestFile(t, tempDir, "setup-go/action.yml", setupGoAction)
+	writeTestFile(t, tempDir, "docker/lint/action.yaml", "name: Lint\nruns:\n  using: docker\n  image: Dockerfile\n")
+	writeTestFile(t, tempDir, "setup-go/workflow.yml", "name: Not an action\n")
+	writeTestFile(t, tempDir, "node_modules/dep/action.yml", "name: Dependency\n")
+
+	docs, err := ParseActionsDirectory(tempDir, ParseOptions{})
+	if err != nil {
+		t.Fatalf("ParseActionsDirectory failed: %v", err)
+	}
+
+	var names []string
+	for _, doc := range docs {
+		names = append(names, doc.DisplayName())
+	}
+	if !slices.Equal(names, []string{"Lint", "Setup Go"}) {
+		t.Errorf("Expected actions [Lint Setup Go], got %v", names)
+	}
+
+	link := filepath.Join(t.TempDir(), "actions")
+	if err := os.Symlink(tempDir, link); err != nil {
+		t.Skipf("Symlinks are not supported: %v", err)
+	}
+	docs, err = ParseActionsDirectory(link, ParseOptions{})
+	if err != nil || len(docs) != 2 || docs[1].FilePath != filepath.Join(link, "setup-go", "action.yml") {
+		t.Errorf("Expected the actions below a symlinked directory, got %v, %v", docs, err)
+	}
+
+	docs, err = ParseActionsDirectory(filepath.Join(tempDir, "missing"), ParseOptions{})
+	if err != nil || len(docs) != 0 {
+		t.Errorf("Expected no actions and no error for a missing directory, got %v, %v", docs, err)
+	}
+}
+
+func TestMarkdownActions(t *testing.T) {
+	tempDir := t.TempDir()
+	action, err := ParseActionFile(writeTestFile(t, tempDir, "setup-go/action.yml", setupGoAction))
+	if err != nil {
+		t.Fatalf("ParseActionFile failed: %v", err)
+	}
+	action.Dir = ".github/actions/setup-go"
+
+	output, err := RenderMarkdownTable([]*WorkflowDoc{{Name: "CI", FileName: "ci.yml"}}, RenderOptions{
+		CustomKeys: []string{"runbook"},
+		Actions:    []*ActionDoc{action},
+	})
+	if err != nil {
+		t.Fatalf("RenderMarkdownTable failed: %v", err)
+	}
+
+	expected := []string{
+		"## Actions\n\n| Action | Description | Runs | Path |\n|--------|-------------|------|------|\n",
+		"| Setup Go | Installs Go. Restores the module cache when enabled. | `composite` | `./.github/actions/setup-go` |\n",
+		"### Setup Go\n\nInstalls Go.\nRestores the module cache when enabled.\n\n**Runs:** `composite`\n\n**Author:** Platform team\n\n",
+		"**Branding:** icon `package`, color `blue`\n",
+		"```yaml\n- uses: ./.github/actions/setup-go\n",
+		"| `go-version` | yes | - | Go version to install |\n",
+		"| `legacy` | no | - | Old switch **Deprecated:** Use cache instead |\n",
+		"| `go-path` | Path of the Go binary | `${{ steps.setup.outputs.path }}` |\n",
+		"**Runbook:** docs/setup-go.md\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	t.Run("no actions section without actions", func(t *testing.T) {
+		output, err := RenderMarkdownTable(nil, RenderOptions{})
+		if err != nil {
+			t.Fatalf("RenderMarkdownTable failed: %v", err)
+		}
+		if strings.Contains(output, "## Actions") {
+			t.Errorf("Did not expect an Actions section, got:\n%s", output)
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package workflowdocgen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// annotationPattern matches a well-formed annotation of any kind; the kinds
+// and fields of a file are checked by the annotationScanner
+var annotationPattern = regexp.MustCompile(`^#\s*@([a-z]+)\.([A-Za-z0-9_-]+):\s*(.*)$`)
+
+var (
+	topLevelFieldPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+	nestedFieldPattern   = regexp.MustCompile(`^[a-z]+$`)
+)
+
+// annotationScanner reads the comment annotations of a workflow or action
+// file. The workflow and action parsers share it and apply what it finds to
+// their documents.
+type annotationScanner struct {
+	file string
+	// kinds are the annotation kinds of the file; the first is the top-level
+	// kind, e.g. workflow, see nearMiss
+	kinds []string
+	// fields are the built-in fields of the top-level kind; other fields are
+	// kept in Extra, and logged unless they are listed in customKeys
+	fields     []string
+	customKeys []string
+	// nested maps kinds that are attached to the YAML by position, such as
+	// job and step, to their fields
+	nested map[string][]string
+	// noteKinds are the kinds of notes for declared inputs, outputs and secrets
+	noteKinds []string
+}
+
+// scannedAnnotations are the annotations of a file found by an annotationScanner
+type scannedAnnotations struct {
+	// fields are the built-in top-level annotations, in order
+	fields []annotation
+	// extra holds the other top-level annotations, by key
+	extra map[string]string
+	// positions holds where each top-level annotation starts, by key
+	positions map[string]Position
+	// nested are the annotations of nested kinds, in order
+	nested []annotation
+	// notes holds the values of notes and notePositions where they start, by
+	// "kind.name"
+	notes         map[string]string
+	notePositions map[string]Position
+	diagnostics   []Diagnostic
+}
+
+// scan reads the annotations of content
+func (s annotationScanner) scan(content []byte) (*scannedAnnotations, error) {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &scannedAnnotations{
+		notes:         make(map[string]string),
+		notePositions: make(map[string]Position),
+	}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		lineNumber := i + 1
+		trimmed := strings.TrimSpace(line)
+
+		// Nested annotations are usually indented; remember their position so
+		// they can be attached to the YAML nodes they document
+		column := indentColumn(line)
+
+		matches := annotationPattern.FindStringSubmatch(trimmed)
+		if !strings.HasPrefix(trimmed, "# @") || matches == nil {
+			result.reportNearMiss(s, trimmed, lineNumber, column)
+			continue
+		}
+		kind, field := matches[1], matches[2]
+
+		switch {
+		case kind == s.kinds[0] && column == 1 && topLevelFieldPattern.MatchString(field):
+			var value string
+			value, i = annotationValue(matches[3], lines, i+1, column)
+
+			if result.positions == nil {
+				result.positions = make(map[string]Position)
+			}
+			if previous, ok := result.positions[field]; ok {
+				result.report(s.file, lineNumber, column, RuleDuplicateAnnotation, "duplicate annotation @%s.%s overrides the one on line %d", kind, field, previous.Line)
+			}
+			result.positions[field] = Position{Line: lineNumber, Column: column}
+
+			if slices.Contains(s.fields, field) {
+				result.fields = append(result.fields, annotation{kind, field, value, lineNumber, column})
+				continue
+			}
+			if !slices.Contains(s.customKeys, field) {
+				slog.Warn("Unknown "+kind+" annotation", "file", s.file, "line", lineNumber, "key", "@"+kind+"."+field)
+			}
+			if result.extra == nil {
+				result.extra = make(map[string]string)
+			}
+			result.extra[field] = value
+
+		case s.nested[kind] != nil && nestedFieldPattern.MatchString(field):
+			var value string
+			value, i = annotationValue(matches[3], lines, i+1, column)
+			if !slices.Contains(s.nested[kind], field) {
+				result.report(s.file, lineNumber, column, RuleMalformedAnnotation, "unknown annotation @%s.%s, expected one of: %s", kind, field, strings.Join(s.nested[kind], ", "))
+				continue
+			}
+			result.nested = append(result.nested, annotation{kind, field, value, lineNumber, column})
+
+		case slices.Contains(s.noteKinds, kind):
+			var value string
+			value, i = annotationValue(matches[3], lines, i+1, column)
+			key := kind + "." + field
+			if previous, ok := result.notePositions[key]; ok {
+				result.report(s.file, lineNumber, column, RuleDuplicateAnnotation, "duplicate annotation @%s overrides the one on line %d", key, previous.Line)
+			}
+			result.notes[key] = value
+			result.notePositions[key] = Position{Line: lineNumber, Column: column}
+
+		default:
+			result.reportNearMiss(s, trimmed, lineNumber, column)
+		}
+	}
+
+	return result, nil
+}
+
+// report adds a warning at the given position
+func (r *scannedAnnotations) report(file string, line, column int, rule, format string, args ...any) {
+	r.diagnostics = append(r.diagnostics, Diagnostic{
+		File:     file,
+		Line:     line,
+		Column:   column,
+		Severity: SeverityWarning,
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// reportNearMiss reports a comment that looks like an annotation of the file
+// but is not one
+func (r *scannedAnnotations) reportNearMiss(s annotationScanner, trimmed string, line, column int) {
+	if message := nearMiss(trimmed, column, s.kinds); message != "" {
+		r.report(s.file, line, column, RuleMalformedAnnotation, "%s", message)
+	}
+}
+
+// checkNotes reports the notes for inputs, outputs and secrets that the file
+// does not declare
+func (r *scannedAnnotations) checkNotes(file string, declares func(kind, name string) bool) {
+	for key, pos := range r.notePositions {
+		kind, name, _ := strings.Cut(key, ".")
+		if !declares(kind, name) {
+			r.report(file, pos.Line, pos.Column, RuleUnattachedAnnotation, "@%s does not match any declared %s", key, noteTargets[kind])
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package workflowdocgen
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// WorkflowRef is the reusable workflow a job calls with uses:, either
+// ./.github/workflows/x.yml in the same repository or
+// owner/repo/.github/workflows/x.yml@ref in another one
+type WorkflowRef struct {
+	// Uses is the uses: value as written
+	Uses string
+	// Repo is owner/repo, or "" for a workflow of the same repository
+	Repo string
+	// Path is the path of the workflow file in its repository, e.g.
+	// .github/workflows/build.yml
+	Path string
+	// Ref is the branch, tag or SHA after @, or "" for a local workflow
+	Ref string
+	// Position is where the uses: value is declared
+	Position Position
+	// Missing is set by the parser for a local reference to a file that does
+	// not exist next to the calling workflow
+	Missing bool
+}
+
+// IsLocal reports whether the reference is to a workflow of the same repository
+func (r WorkflowRef) IsLocal() bool {
+	return r.Repo == ""
+}
+
+// parseWorkflowRef parses the uses: value of a job. It returns false for
+// values that do not refer to a reusable workflow.
+func parseWorkflowRef(uses string) (WorkflowRef, bool) {
+	if rest, ok := strings.CutPrefix(uses, "./"); ok {
+		if !strings.HasPrefix(rest, ".github/workflows/") {
+			return WorkflowRef{}, false
+		}
+		return WorkflowRef{Uses: uses, Path: path.Clean(rest)}, true
+	}
+
+	target, ref, _ := strings.Cut(uses, "@")
+	owner, rest, _ := strings.Cut(target, "/")
+	repo, file, _ := strings.Cut(rest, "/")
+	if owner == "" || repo == "" || !strings.HasPrefix(file, ".github/workflows/") {
+		return WorkflowRef{}, false
+	}
+	return WorkflowRef{Uses: uses, Repo: owner + "/" + repo, Path: file, Ref: ref}, true
+}
+
+// checkWorkflowRefs marks the local references of the jobs of doc to files
+// that do not exist next to the workflow file, and reports them. Reusable
+// workflows must be directly in .github/workflows, so only the file name of
+// the reference is looked up.
+func checkWorkflowRefs(doc *WorkflowDoc) {
+	for _, job := range doc.Jobs {
+		if job.Call == nil || !job.Call.IsLocal() {
+			continue
+		}
+		_, err := os.Stat(filepath.Join(filepath.Dir(doc.FilePath), path.Base(job.Call.Path)))
+		if !os.IsNotExist(err) {
+			continue
+		}
+		job.Call.Missing = true
+		doc.Diagnostics = append(doc.Diagnostics, Diagnostic{
+			File:     doc.FilePath,
+			Line:     job.Call.Position.Line,
+			Column:   job.Call.Position.Column,
+			Severity: SeverityError,
+			Rule:     RuleMissingWorkflow,
+			Message:  fmt.Sprintf("job %s calls %s, which does not exist", job.ID, job.Call.Uses),
+		})
+	}
+}
+
+// CallEdge is a job of one workflow that calls another workflow
+type CallEdge struct {
+	Caller *WorkflowDoc
+	Job    *JobDoc
+	Ref    WorkflowRef
+	// Callee is the called workflow if it is one of the documented workflows
+	Callee *WorkflowDoc
+	// Missing is set for a local reference to a file that does not exist and
+	// is not documented either
+	Missing bool
+}
+
+// Target returns the file name of the called workflow if it is documented,
+// or the uses: value otherwise
+func (e CallEdge) Target() string {
+	if e.Callee != nil {
+		return e.Callee.FileName
+	}
+	return e.Ref.Uses
+}
+
+// CallGraph holds the calls between reusable workflows
+type CallGraph struct {
+	Edges []CallEdge
+}
+
+// BuildCallGraph collects the reusable workflow calls of the jobs of docs and
+// resolves local references to the documented workflows. It does not access
+// the file system: a local reference is missing if it matches no documented
+// workflow and the parser found no file for it.
+func BuildCallGraph(docs []*WorkflowDoc) *CallGraph {
+	graph := &CallGraph{}
+	for _, doc := range docs {
+		for _, job := range doc.Jobs {
+			if job.Call == nil {
+				continue
+			}
+			edge := CallEdge{Caller: doc, Job: job, Ref: *job.Call}
+			if edge.Ref.IsLocal() {
+				edge.Callee = resolveLocalWorkflow(docs, doc, edge.Ref.Path)
+				edge.Missing = edge.Callee == nil && edge.Ref.Missing
+			}
+			graph.Edges = append(graph.Edges, edge)
+		}
+	}
+	return graph
+}
+
+// resolveLocalWorkflow finds the documented workflow at refPath, a path
+// relative to the repository root. Workflows are matched by path, or by file
+// name in the directory of the caller, because documented paths may be
+// relative to another directory or absolute.
+func resolveLocalWorkflow(docs []*WorkflowDoc, caller *WorkflowDoc, refPath string) *WorkflowDoc {
+	for _, doc := range docs {
+		if p := filepath.ToSlash(filepath.Clean(doc.FilePath)); p == refPath || strings.HasSuffix(p, "/"+refPath) {
+			return doc
+		}
+	}
+	for _, doc := range docs {
+		if filepath.Dir(doc.FilePath) == filepath.Dir(caller.FilePath) && doc.FileName == path.Base(refPath) {
+			return doc
+		}
+	}
+	return nil
+}
+
+// Calls returns the calls made by the jobs of doc
+func (g *CallGraph) Calls(doc *WorkflowDoc) []CallEdge {
+	var edges []CallEdge
+	for _, edge := range g.Edges {
+		if edge.Caller == doc {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// CalledBy returns the calls of doc made by other documented workflows
+func (g *CallGraph) CalledBy(doc *WorkflowDoc) []CallEdge {
+	var edges []CallEdge
+	for _, edge := range g.Edges {
+		if edge.Callee == doc {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// Mermaid returns the graph as a Mermaid flowchart, or "" if there are no
+// calls. Workflows of other repositories are drawn as subroutines and
+// missing workflows with a dashed red border.
+func (g *CallGraph) Mermaid() string {
+	if len(g.Edges) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+
+	ids := make(map[string]string)
+	node := func(key, shape string) string {
+		if id, ok := ids[key]; ok {
+			return id
+		}
+		id := fmt.Sprintf("w%d", len(ids))
+		ids[key] = id
+		sb.WriteString(fmt.Sprintf("    %s%s\n", id, shape))
+		return id
+	}
+	workflowNode := func(doc *WorkflowDoc) string {
+		return node("file:"+doc.FilePath, fmt.Sprintf("[%q]", mermaidLabel(doc.FileName)))
+	}
+
+	var edges []string
+	missing := false
+	for _, edge := range g.Edges {
+		from := workflowNode(edge.Caller)
+		var to string
+		switch {
+		case edge.Callee != nil:
+			to = workflowNode(edge.Callee)
+		case edge.Missing:
+			to = node("missing:"+edge.Ref.Path, fmt.Sprintf("[%q]:::missing", mermaidLabel(edge.Ref.Uses)))
+			missing = true
+		case edge.Ref.IsLocal():
+			to = node("local:"+edge.Ref.Path, fmt.Sprintf("[%q]", mermaidLabel(edge.Ref.Uses)))
+		default:
+			to = node("remote:"+edge.Ref.Uses, fmt.Sprintf("[[%q]]", mermaidLabel(edge.Ref.Uses)))
+		}
+		edges = append(edges, fmt.Sprintf("    %s -->|%s| %s\n", from, mermaidLabel(edge.Job.ID), to))
+	}
+
+	for _, edge := range edges {
+		sb.WriteString(edge)
+	}
+	if missing {
+		sb.WriteString("    classDef missing stroke:#d00,stroke-dasharray:4 4\n")
+	}
+	return sb.String()
+}
+
+// mermaidLabel escapes the characters that end a Mermaid label
+func mermaidLabel(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "|", "#124;").Replace(s)
+}
@@ -0,0 +1,173 @@
+package workflowdocgen
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseWorkflowRef(t *testing.T) {
+	tests := []struct {
+		uses string
+		ok   bool
+		want WorkflowRef
+	}{
+		{"./.github/workflows/build.yml", true, WorkflowRef{Uses: "./.github/workflows/build.yml", Path: ".github/workflows/build.yml"}},
+		{"acme/shared/.github/workflows/deploy.yml@v1", true, WorkflowRef{Uses: "acme/shared/.github/workflows/deploy.yml@v1", Repo: "acme/shared", Path: ".github/workflows/deploy.yml", Ref: "v1"}},
+		{"actions/checkout@v4", false, WorkflowRef{}},
+		{"./.github/actions/setup-go", false, WorkflowRef{}},
+		{"docker://alpine:3", false, WorkflowRef{}},
+		{"", false, WorkflowRef{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uses, func(t *testing.T) {
+			got, ok := parseWorkflowRef(tt.uses)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok %v, got %v", tt.ok, ok)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCallGraph(t *testing.T) {
+	tempDir := t.TempDir()
+	workflowsDir := filepath.Join(tempDir, ".github", "workflows")
+
+	writeTestFile(t, workflowsDir, "build.yml", `on:
+  workflow_call:
+jobs:
+  compile:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`)
+	writeTestFile(t, workflowsDir, "ci.yml", `on: push
+jobs:
+  build:
+    uses: ./.github/workflows/build.yml
+  deploy:
+    needs: build
+    uses: acme/shared/.github/workflows/deploy.yml@v1
+  publish:
+    uses: ./.github/workflows/publish.yml
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+`)
+
+	docs, err := ParseWorkflowsDirectory(workflowsDir)
+	if err != nil {
+		t.Fatalf("Failed to parse workflows: %v", err)
+	}
+	build, ci := docs[0], docs[1]
+
+	t.Run("job call is parsed", func(t *testing.T) {
+		call := ci.Jobs[0].Call
+		if call == nil {
+			t.Fatal("Expected the build job to call a workflow")
+		}
+		if call.Position != (Position{Line: 4, Column: 11}) {
+			t.Errorf("Expected position 4:11, got %d:%d", call.Position.Line, call.Position.Column)
+		}
+		if ci.Jobs[3].Call != nil {
+			t.Errorf("Expected no call for a job with steps, got %+v", ci.Jobs[3].Call)
+		}
+	})
+
+	graph := BuildCallGraph(docs)
+
+	t.Run("calls and called by", func(t *testing.T) {
+		calls := graph.Calls(ci)
+		if len(calls) != 3 {
+			t.Fatalf("Expected 3 calls, got %d", len(calls))
+		}
+		if calls[0].Callee != build || calls[0].Target() != "build.yml" {
+			t.Errorf("Expected the build job to call build.yml, got '%s'", calls[0].Target())
+		}
+		if calls[1].Callee != nil || calls[1].Missing || calls[1].Target() != "acme/shared/.github/workflows/deploy.yml@v1" {
+			t.Errorf("Expected an external call, got %+v", calls[1])
+		}
+		if !calls[2].Missing {
+			t.Error("Expected publish.yml to be missing")
+		}
+
+		calledBy := graph.CalledBy(build)
+		if len(calledBy) != 1 || calledBy[0].Caller != ci || calledBy[0].Job.ID != "build" {
+			t.Errorf("Expected build.yml to be called by the build job of ci.yml, got %+v", calledBy)
+		}
+		if len(graph.Calls(build)) != 0 || len(graph.CalledBy(ci)) != 0 {
+			t.Error("Expected build.yml to call nothing and ci.yml to be called by nothing")
+		}
+	})
+
+	t.Run("missing workflows are reported", func(t *testing.T) {
+		if len(ci.Diagnostics) != 1 {
+			t.Fatalf("Expected 1 diagnostic, got %d", len(ci.Diagnostics))
+		}
+		want := ci.FilePath + ":9:11: error: job publish calls ./.github/workflows/publish.yml, which does not exist"
+		if ci.Diagnostics[0].String() != want || ci.Diagnostics[0].Rule != RuleMissingWorkflow {
+			t.Errorf("Expected '%s', got '%s'", want, ci.Diagnostics[0])
+		}
+		if !ci.Jobs[2].Call.Missing || ci.Jobs[0].Call.Missing {
+			t.Error("Expected only the call of publish.yml to be marked as missing")
+		}
+	})
+
+	t.Run("rendering does not access the file system", func(t *testing.T) {
+		// The parser found build.yml; the working directory has no such file
+		t.Chdir(t.TempDir())
+		ref, _ := parseWorkflowRef("./.github/workflows/build.yml")
+		inMemory := &WorkflowDoc{
+			FileName: "ci.yml",
+			FilePath: ".github/workflows/ci.yml",
+			Jobs:     []*JobDoc{{ID: "build", Uses: ref.Uses, Call: &ref}},
+		}
+		content, err := RenderMarkdownTable([]*WorkflowDoc{inMemory}, RenderOptions{})
+		if err != nil {
+			t.Fatalf("Failed to render markdown: %v", err)
+		}
+		if strings.Contains(content, "not found") || strings.Contains(content, ":::missing") {
+			t.Errorf("Expected the call not to be flagged, got:\n%s", content)
+		}
+	})
+
+	t.Run("mermaid", func(t *testing.T) {
+		want := `flowchart LR
+    w0["ci.yml"]
+    w1["build.yml"]
+    w2[["acme/shared/.github/workflows/deploy.yml@v1"]]
+    w3["./.github/workflows/publish.yml"]:::missing
+    w0 -->|build| w1
+    w0 -->|deploy| w2
+    w0 -->|publish| w3
+    classDef missing stroke:#d00,stroke-dasharray:4 4
+`
+		if got := graph.Mermaid(); got != want {
+			t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+		}
+		if got := BuildCallGraph([]*WorkflowDoc{build}).Mermaid(); got != "" {
+			t.Errorf("Expected no graph without calls, got '%s'", got)
+		}
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		content, err := RenderMarkdownTable(docs, RenderOptions{})
+		if err != nil {
+			t.Fatalf("Failed to render markdown: %v", err)
+		}
+		for _, want := range []string{
+			"**Calls:**\n\n- `build.yml` from job `build`\n- `acme/shared/.github/workflows/deploy.yml@v1` from job `deploy`\n- `./.github/workflows/publish.yml` from job `publish` (**not found**)\n",
+			"**Called by:**\n\n- `ci.yml` from job `build`\n",
+			"## Workflow Call Graph\n\n```mermaid\nflowchart LR\n",
+		} {
+			if !strings.Contains(content, want) {
+				t.Errorf("Expected markdown to contain '%s', got:\n%s", want, content)
+			}
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package workflowdocgen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName is the name of the configuration file looked up at the repository root
+const ConfigFileName = ".workflowdocgen.yml"
+
+// Config is the content of a configuration file. Relative paths are resolved
+// against the directory of the file by LoadConfig.
+type Config struct {
+	WorkflowsDirs  []string `yaml:"workflows-dirs"`
+	Recursive      bool     `yaml:"recursive"`
+	ActionsDirs    []string `yaml:"actions-dirs"`
+	Include        []string `yaml:"include"`
+	Exclude        []string `yaml:"exclude"`
+	Format         string   `yaml:"format"`
+	Output         string   `yaml:"output"`
+	Template       string   `yaml:"template"`
+	CustomKeys     []string `yaml:"custom-keys"`
+	RequiredFields []string `yaml:"required-fields"`
+	Columns        []string `yaml:"columns"`
+	Sort           string   `yaml:"sort"`
+	// Lint holds the rules of the lint command in addition to RequiredFields
+	Lint LintConfig `yaml:"lint"`
+	// Dir is the directory of the file, or "" for an empty configuration
+	Dir string `yaml:"-"`
+}
+
+// LintConfig is the lint section of a configuration file
+type LintConfig struct {
+	AllowedTags          []string `yaml:"allowed-tags"`
+	OwnerPattern         string   `yaml:"owner-pattern"`
+	MaxDescriptionLength int      `yaml:"max-description-length"`
+	NameMismatch         bool     `yaml:"name-mismatch"`
+}
+
+// FindConfig looks for ConfigFileName at the root of the git repository that
+// contains dir, or in dir itself when it is not inside a repository. It
+// returns "" if there is no configuration file.
+func FindConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	root := dir
+	for current := dir; ; {
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			root = current
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	path := filepath.Join(root, ConfigFileName)
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return path, nil
+}
+
+// LoadConfig reads and validates a configuration file. Unknown keys are errors.
+func LoadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path) // #nosec G304 - config path is provided by the user or found at the repository root
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	// Paths in the file are relative to the file, not to the working directory
+	config.Dir = filepath.Dir(path)
+	for i, dir := range config.WorkflowsDirs {
+		config.WorkflowsDirs[i] = config.Resolve(dir)
+	}
+	for i, dir := range config.ActionsDirs {
+		config.ActionsDirs[i] = config.Resolve(dir)
+	}
+	config.Output = config.Resolve(config.Output)
+	config.Template = config.Resolve(config.Template)
+
+	return &config, nil
+}
+
+// validate checks the values that have a fixed set of choices
+func (c *Config) validate() error {
+	if c.Format != "" && !slices.Contains(Formats(), c.Format) {
+		return fmt.Errorf("unknown format %q, expected one of: %s", c.Format, strings.Join(Formats(), ", "))
+	}
+	if err := ValidateColumns(c.Columns); err != nil {
+		return err
+	}
+	if c.Sort != "" && !slices.Contains(SortOrders, c.Sort) {
+		return fmt.Errorf("unknown sort order %q, expected one of: %s", c.Sort, strings.Join(SortOrders, ", "))
+	}
+	if _, err := regexp.Compile(c.Lint.OwnerPattern); err != nil {
+		return fmt.Errorf("invalid owner pattern %q: %w", c.Lint.OwnerPattern, err)
+	}
+	if c.Lint.MaxDescriptionLength < 0 {
+		return fmt.Errorf("max description length must not be negative, got %d", c.Lint.MaxDescriptionLength)
+	}
+	for _, pattern := range append(slices.Clone(c.Include), c.Exclude...) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Resolve makes a relative path relative to the directory of the file, such
+// as a default that is meant to be relative to the repository root. Paths of
+// an empty configuration, "" and "-" are returned unchanged.
+func (c *Config) Resolve(path string) string {
+	if c.Dir == "" || path == "" || path == "-" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(c.Dir, path)
+}
@@ -0,0 +1,169 @@
+package workflowdocgen
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFindConfig(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0750); err != nil {
+		t.Fatalf("Failed to create .git: %v", err)
+	}
+	if err := os.MkdirAll(nested, 0750); err != nil {
+		t.Fatalf("Failed to create directories: %v", err)
+	}
+
+	t.Run("no config file", func(t *testing.T) {
+		path, err := FindConfig(nested)
+		if err != nil {
+			t.Fatalf("FindConfig failed: %v", err)
+		}
+		if path != "" {
+			t.Errorf("Expected no config file, got '%s'", path)
+		}
+	})
+
+	configPath := filepath.Join(root, ConfigFileName)
+	if err := os.WriteFile(configPath, []byte("format: json\n"), 0600); err != nil { // #nosec G306 - test file
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+
+	t.Run("config at repository root", func(t *testing.T) {
+		path, err := FindConfig(nested)
+		if err != nil {
+			t.Fatalf("FindConfig failed: %v", err)
+		}
+		if path != configPath {
+			t.Errorf("Expected '%s', got '%s'", configPath, path)
+		}
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	tempDir := t.TempDir()
+
+	writeConfig := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(tempDir, ConfigFileName)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil { // #nosec G306 - test file
+			t.Fatalf("Failed to create config file: %v", err)
+		}
+		return path
+	}
+
+	t.Run("all settings", func(t *testing.T) {
+		path := writeConfig(t, `workflows-dirs: [.github/workflows, /abs/workflows]
+actions-dirs: [.github/actions]
+include: ["*.yml"]
+exclude: ["experimental-*"]
+format: html
+output: docs/workflows.html
+template: docs/layout.tmpl
+custom-keys: [runbook]
+required-fields: [description, owners]
+columns: [workflow, owners]
+sort: name
+lint:
+  allowed-tags: [ci, release]
+  owner-pattern: ^acme/
+  max-description-length: 200
+  name-mismatch: true
+`)
+		config, err := LoadConfig(path)
+		if err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+
+		wantDirs := []string{filepath.Join(tempDir, ".github/workflows"), "/abs/workflows"}
+		if !slices.Equal(config.WorkflowsDirs, wantDirs) {
+			t.Errorf("Expected workflows dirs %v, got %v", wantDirs, config.WorkflowsDirs)
+		}
+		if !slices.Equal(config.ActionsDirs, []string{filepath.Join(tempDir, ".github/actions")}) {
+			t.Errorf("Expected actions dirs relative to the config file, got %v", config.ActionsDirs)
+		}
+		if config.Output != filepath.Join(tempDir, "docs/workflows.html") {
+			t.Errorf("Expected output relative to the config file, got '%s'", config.Output)
+		}
+		if config.Template != filepath.Join(tempDir, "docs/layout.tmpl") {
+			t.Errorf("Expected template relative to the config file, got '%s'", config.Template)
+		}
+		if config.Format != "html" || config.Sort != "name" {
+			t.Errorf("Unexpected config %+v", config)
+		}
+		if !slices.Equal(config.RequiredFields, []string{"description", "owners"}) {
+			t.Errorf("Expected required fields, got %v", config.RequiredFields)
+		}
+		if !slices.Equal(config.Columns, []string{"workflow", "owners"}) {
+			t.Errorf("Expected columns, got %v", config.Columns)
+		}
+		if !slices.Equal(config.Lint.AllowedTags, []string{"ci", "release"}) || config.Lint.OwnerPattern != "^acme/" || config.Lint.MaxDescriptionLength != 200 || !config.Lint.NameMismatch {
+			t.Errorf("Unexpected lint config %+v", config.Lint)
+		}
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		config, err := LoadConfig(writeConfig(t, ""))
+		if err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+		if config.Output != "" || len(config.WorkflowsDirs) != 0 {
+			t.Errorf("Expected empty config, got %+v", config)
+		}
+	})
+
+	t.Run("stdout output is kept", func(t *testing.T) {
+		config, err := LoadConfig(writeConfig(t, "output: \"-\"\n"))
+		if err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+		if config.Output != "-" {
+			t.Errorf("Expected output '-', got '%s'", config.Output)
+		}
+	})
+
+	t.Run("defaults are resolved against the config file", func(t *testing.T) {
+		config, err := LoadConfig(writeConfig(t, ""))
+		if err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+		if got := config.Resolve(".github/workflows"); got != filepath.Join(tempDir, ".github/workflows") {
+			t.Errorf("Expected path relative to the config file, got '%s'", got)
+		}
+		if got := (&Config{}).Resolve(".github/workflows"); got != ".github/workflows" {
+			t.Errorf("Expected path of an empty config to be unchanged, got '%s'", got)
+		}
+	})
+
+	errorTests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown key", "formats: json\n", "field formats not found"},
+		{"unknown format", "format: pdf\n", `unknown format "pdf"`},
+		{"unknown column", "columns: [workflow, size]\n", `unknown column "size"`},
+		{"unknown sort order", "sort: date\n", `unknown sort order "date"`},
+		{"invalid glob", "include: [\"[\"]\n", `invalid glob "["`},
+		{"invalid owner pattern", "lint:\n  owner-pattern: \"(\"\n", `invalid owner pattern "("`},
+		{"negative description length", "lint:\n  max-description-length: -1\n", "must not be negative"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfig(writeConfig(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing '%s', got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := LoadConfig(filepath.Join(tempDir, "missing.yml")); err == nil {
+			t.Error("Expected error for missing file, got nil")
+		}
+	})
+}
@@ -0,0 +1,264 @@
+package workflowdocgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// cronFieldSpec describes the valid range, value names and unit of a cron field
+type cronFieldSpec struct {
+	min, max int
+	names    []string
+	unit     string
+}
+
+var (
+	cronMinute  = cronFieldSpec{min: 0, max: 59, unit: "minute"}
+	cronHour    = cronFieldSpec{min: 0, max: 23, unit: "hour"}
+	cronDay     = cronFieldSpec{min: 1, max: 31, unit: "day of the month"}
+	cronMonth   = cronFieldSpec{min: 1, max: 12, names: []string{"", "January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}, unit: "month"}
+	cronWeekday = cronFieldSpec{min: 0, max: 7, names: []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}, unit: "day of the week"}
+)
+
+// cronField is a parsed cron field
+type cronField struct {
+	any    bool // "*" without a step
+	step   int  // step of "*/n", 0 otherwise
+	values []int
+	parts  []cronPart
+}
+
+// cronPart is a single comma-separated element of a cron field
+type cronPart struct {
+	from, to, step int
+}
+
+// single reports whether the field selects exactly one value
+func (f cronField) single() bool {
+	return len(f.parts) == 1 && f.parts[0].from == f.parts[0].to
+}
+
+// DescribeCron translates a five-field POSIX cron expression, as used by
+// GitHub Actions schedules, into plain English. Times are in UTC.
+func DescribeCron(expr string) (string, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return "", fmt.Errorf("cron expression %q must have 5 fields", expr)
+	}
+
+	specs := []cronFieldSpec{cronMinute, cronHour, cronDay, cronMonth, cronWeekday}
+	parsed := make([]cronField, len(fields))
+	for i, field := range fields {
+		f, err := parseCronField(field, specs[i])
+		if err != nil {
+			return "", fmt.Errorf("cron expression %q: %w", expr, err)
+		}
+		parsed[i] = f
+	}
+	minute, hour, day, month, weekday := parsed[0], parsed[1], parsed[2], parsed[3], parsed[4]
+
+	phrases := []string{describeCronTime(minute, hour)}
+
+	switch {
+	case day.any && weekday.any:
+		if minute.single() && !hour.any && hour.step == 0 {
+			phrases = append(phrases, "every day")
+		}
+	case weekday.any:
+		phrases = append(phrases, describeCronDays(day))
+	case day.any:
+		phrases = append(phrases, "on "+describeCronField(weekday, cronWeekday))
+	default:
+		// POSIX cron runs when either the day of month or the weekday matches
+		phrases = append(phrases, describeCronDays(day)+" or on "+describeCronField(weekday, cronWeekday))
+	}
+
+	if !month.any {
+		phrases = append(phrases, "in "+describeCronField(month, cronMonth))
+	}
+
+	return strings.Join(phrases, " "), nil
+}
+
+// describeCronTime describes the minute and hour fields
+func describeCronTime(minute, hour cronField) string {
+	if minute.single() && hour.single() {
+		return fmt.Sprintf("At %02d:%02d UTC", hour.parts[0].from, minute.parts[0].from)
+	}
+
+	if minute.single() && len(hour.values) > 0 && len(hour.values) == len(hour.parts) {
+		times := make([]string, len(hour.values))
+		for i, h := range hour.values {
+			times[i] = fmt.Sprintf("%02d:%02d", h, minute.parts[0].from)
+		}
+		return "At " + joinWords(times, "and") + " UTC"
+	}
+
+	var phrase string
+	switch {
+	case minute.any:
+		phrase = "Every minute"
+	case minute.step > 0:
+		phrase = fmt.Sprintf("Every %d minutes", minute.step)
+	case minute.single():
+		phrase = fmt.Sprintf("At minute %d past", minute.parts[0].from)
+		if hour.any {
+			return phrase + " every hour"
+		}
+	default:
+		phrase = "At minutes " + describeCronField(minute, cronMinute) + " past"
+		if hour.any {
+			return phrase + " every hour"
+		}
+	}
+
+	switch {
+	case hour.any:
+		return phrase
+	case hour.step > 0:
+		return fmt.Sprintf("%s every %d hours", phrase, hour.step)
+	}
+	hours := "hours"
+	if hour.single() {
+		hours = "hour"
+	}
+	if strings.HasSuffix(phrase, "past") {
+		return fmt.Sprintf("%s %s %s UTC", phrase, hours, describeCronField(hour, cronHour))
+	}
+	return fmt.Sprintf("%s during %s %s UTC", phrase, hours, describeCronField(hour, cronHour))
+}
+
+// describeCronDays describes the day-of-month field
+func describeCronDays(day cronField) string {
+	if day.step > 0 {
+		return fmt.Sprintf("every %d days", day.step)
+	}
+	if day.single() {
+		return fmt.Sprintf("on day %d of the month", day.parts[0].from)
+	}
+	return "on days " + describeCronField(day, cronDay) + " of the month"
+}
+
+// describeCronField describes the values of a restricted field, e.g.
+// "Monday through Friday" or "every 2nd day of the week"
+func describeCronField(f cronField, spec cronFieldSpec) string {
+	if f.step > 0 {
+		return fmt.Sprintf("every %s %s", ordinal(f.step), spec.unit)
+	}
+	parts := make([]string, len(f.parts))
+	for i, part := range f.parts {
+		switch {
+		case part.from == part.to:
+			parts[i] = spec.name(part.from)
+		case part.step > 1:
+			parts[i] = fmt.Sprintf("every %s %s from %s through %s", ordinal(part.step), spec.unit, spec.name(part.from), spec.name(part.to))
+		default:
+			parts[i] = fmt.Sprintf("%s through %s", spec.name(part.from), spec.name(part.to))
+		}
+	}
+	return joinWords(parts, "and")
+}
+
+// parseCronField parses a single cron field against its spec
+func parseCronField(field string, spec cronFieldSpec) (cronField, error) {
+	var f cronField
+
+	if field == "*" || field == "*/1" {
+		f.any = true
+		return f, nil
+	}
+	if strings.HasPrefix(field, "*/") {
+		step, err := strconv.Atoi(field[2:])
+		if err != nil || step <= 0 {
+			return f, fmt.Errorf("invalid step in %q", field)
+		}
+		f.step = step
+		return f, nil
+	}
+
+	for _, element := range strings.Split(field, ",") {
+		part := cronPart{step: 1}
+
+		rangeExpr := element
+		if before, after, found := strings.Cut(element, "/"); found {
+			step, err := strconv.Atoi(after)
+			if err != nil || step <= 0 {
+				return f, fmt.Errorf("invalid step in %q", element)
+			}
+			rangeExpr, part.step = before, step
+		}
+
+		from, to, isRange := strings.Cut(rangeExpr, "-")
+		var err error
+		if part.from, err = spec.value(from); err != nil {
+			return f, err
+		}
+		part.to = part.from
+		if isRange {
+			if part.to, err = spec.value(to); err != nil {
+				return f, err
+			}
+		} else if part.step > 1 {
+			part.to = spec.max
+		}
+		if part.to < part.from {
+			return f, fmt.Errorf("invalid range %q", element)
+		}
+
+		f.parts = append(f.parts, part)
+		for v := part.from; v <= part.to; v += part.step {
+			f.values = append(f.values, v)
+		}
+	}
+
+	return f, nil
+}
+
+// value parses a numeric or named value of the field
+func (s cronFieldSpec) value(text string) (int, error) {
+	for i, name := range s.names {
+		if name != "" && strings.EqualFold(text, name[:3]) {
+			return i, nil
+		}
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil || v < s.min || v > s.max {
+		return 0, fmt.Errorf("value %q out of range %d-%d", text, s.min, s.max)
+	}
+	return v, nil
+}
+
+// name returns the display name of a field value
+func (s cronFieldSpec) name(v int) string {
+	if v < len(s.names) && s.names[v] != "" {
+		return s.names[v]
+	}
+	return strconv.Itoa(v)
+}
+
+// ordinal returns n as an English ordinal number, e.g. "2nd"
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// joinWords joins items as an English list, e.g. "a, b and c"
+func joinWords(items []string, conjunction string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " " + conjunction + " " + items[len(items)-1]
+}
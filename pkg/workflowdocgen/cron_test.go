@@ -0,0 +1,61 @@
+package workflowdocgen
+
+import "testing"
+
+func TestDescribeCron(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"0 3 * * *", "At 03:00 UTC every day"},
+		{"30 2 * * 1", "At 02:30 UTC on Monday"},
+		{"0 9 * * MON-FRI", "At 09:00 UTC on Monday through Friday"},
+		{"0 0 1 * *", "At 00:00 UTC on day 1 of the month"},
+		{"0 0 1,15 * *", "At 00:00 UTC on days 1 and 15 of the month"},
+		{"0 0 1 * 0", "At 00:00 UTC on day 1 of the month or on Sunday"},
+		{"0 6,18 * * *", "At 06:00 and 18:00 UTC every day"},
+		{"*/15 * * * *", "Every 15 minutes"},
+		{"* * * * *", "Every minute"},
+		{"5 * * * *", "At minute 5 past every hour"},
+		{"0 */6 * * *", "At minute 0 past every 6 hours"},
+		{"*/10 9-17 * * 1-5", "Every 10 minutes during hours 9 through 17 UTC on Monday through Friday"},
+		{"0 12 * JAN,JUL *", "At 12:00 UTC every day in January and July"},
+		{"0 0 * 1 *", "At 00:00 UTC every day in January"},
+		{"0 0 * */3 *", "At 00:00 UTC every day in every 3rd month"},
+		{"0 0 * * */2", "At 00:00 UTC on every 2nd day of the week"},
+		{"0 0 * * 1-5/2", "At 00:00 UTC on every 2nd day of the week from Monday through Friday"},
+		{"0 0 1 * */3", "At 00:00 UTC on day 1 of the month or on every 3rd day of the week"},
+		{"0 0 * 2-12/11 *", "At 00:00 UTC every day in every 11th month from February through December"},
+		{"*/1 * * * *", "Every minute"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := DescribeCron(tt.expr)
+			if err != nil {
+				t.Fatalf("DescribeCron(%q) failed: %v", tt.expr, err)
+			}
+			if got != tt.want {
+				t.Errorf("DescribeCron(%q) = %q, want %q", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeCronInvalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"0 3 * *",
+		"60 * * * *",
+		"0 24 * * *",
+		"*/0 * * * *",
+		"0 0 5-1 * *",
+		"0 0 * * FOO",
+	}
+
+	for _, expr := range invalid {
+		if _, err := DescribeCron(expr); err == nil {
+			t.Errorf("Expected error for cron expression %q", expr)
+		}
+	}
+}
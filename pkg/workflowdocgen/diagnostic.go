@@ -0,0 +1,50 @@
+package workflowdocgen
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Severity is the severity of a Diagnostic
+type Severity string
+
+const (
+	// SeverityError marks a problem that fails the lint run
+	SeverityError Severity = "error"
+	// SeverityWarning marks a problem that is reported but does not fail the run
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a problem found in a workflow file
+type Diagnostic struct {
+	File     string
+	Line     int
+	Column   int
+	Severity Severity
+	// Rule is the name of the check that reported the problem
+	Rule    string
+	Message string
+}
+
+// String formats the diagnostic as file:line:col: severity: message
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s", d.File, d.Line, d.Column, d.Severity, d.Message)
+}
+
+// Rules of the diagnostics reported by the parser
+const (
+	RuleMalformedAnnotation  = "malformed-annotation"
+	RuleDuplicateAnnotation  = "duplicate-annotation"
+	RuleUnattachedAnnotation = "unattached-annotation"
+	RuleInvalidYAML          = "invalid-yaml"
+	RuleMissingWorkflow      = "missing-workflow"
+)
+
+// sortDiagnostics orders diagnostics by position, keeping the order of
+// diagnostics at the same position
+func sortDiagnostics(diagnostics []Diagnostic) {
+	slices.SortStableFunc(diagnostics, func(a, b Diagnostic) int {
+		return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+	})
+}
@@ -0,0 +1,228 @@
+package workflowdocgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// diffOp is a single line of an edit script: ' ' keeps, '-' deletes and '+'
+// inserts the line
+type diffOp struct {
+	kind byte
+	text string
+}
+
+// UnifiedDiff returns a unified diff that turns oldText into newText, using
+// oldName and newName in the file headers. It returns "" when the texts are equal.
+func UnifiedDiff(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	ops := diffLines(splitLines(oldText), splitLines(newText))
+
+	// oldPos and newPos hold the number of lines consumed before each op
+	oldPos := make([]int, len(ops)+1)
+	newPos := make([]int, len(ops)+1)
+	for i, op := range ops {
+		oldPos[i+1], newPos[i+1] = oldPos[i], newPos[i]
+		if op.kind != '+' {
+			oldPos[i+1]++
+		}
+		if op.kind != '-' {
+			newPos[i+1]++
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", oldName, newName))
+
+	for i := 0; i < len(ops); {
+		change := nextChange(ops, i)
+		if change < 0 {
+			break
+		}
+
+		// Merge changes separated by less than two contexts into one hunk
+		end := change + 1
+		for {
+			next := nextChange(ops, end)
+			if next < 0 || next-end > 2*diffContext {
+				break
+			}
+			end = next + 1
+		}
+
+		start := max(change-diffContext, i)
+		end = min(end+diffContext, len(ops))
+
+		sb.WriteString(fmt.Sprintf("@@ -%s +%s @@\n",
+			hunkRange(oldPos[start], oldPos[end]-oldPos[start]),
+			hunkRange(newPos[start], newPos[end]-newPos[start])))
+		for _, op := range ops[start:end] {
+			sb.WriteByte(op.kind)
+			sb.WriteString(op.text)
+			if !strings.HasSuffix(op.text, "\n") {
+				sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+
+	return sb.String()
+}
+
+// nextChange returns the index of the first insert or delete at or after i, or -1
+func nextChange(ops []diffOp, i int) int {
+	for ; i < len(ops); i++ {
+		if ops[i].kind != ' ' {
+			return i
+		}
+	}
+	return -1
+}
+
+// hunkRange formats the start,count part of a hunk header. Empty ranges
+// refer to the line before the hunk, as in GNU diff.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// splitLines splits text into lines that keep their trailing newline
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes a line edit script from a to b with the linear space
+// variant of Myers' algorithm, so large documents can be compared in CI
+func diffLines(a, b []string) []diffOp {
+	d := &differ{a: a, b: b, ops: make([]diffOp, 0, len(a)+len(b))}
+	d.compare(0, len(a), 0, len(b))
+	return d.ops
+}
+
+// differ holds the state of diffLines
+type differ struct {
+	a, b []string
+	ops  []diffOp
+	// forward and reverse are the furthest reaching x per diagonal of
+	// middleSnake, reused between calls
+	forward, reverse []int
+}
+
+// compare appends the edit script from a[aLo:aHi] to b[bLo:bHi]
+func (d *differ) compare(aLo, aHi, bLo, bHi int) {
+	prefix := 0
+	for aLo+prefix < aHi && bLo+prefix < bHi && d.a[aLo+prefix] == d.b[bLo+prefix] {
+		prefix++
+	}
+	for _, line := range d.a[aLo : aLo+prefix] {
+		d.ops = append(d.ops, diffOp{' ', line})
+	}
+	aLo, bLo = aLo+prefix, bLo+prefix
+
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && d.a[aHi-1-suffix] == d.b[bHi-1-suffix] {
+		suffix++
+	}
+	aHi, bHi = aHi-suffix, bHi-suffix
+
+	switch {
+	case aLo == aHi:
+		for _, line := range d.b[bLo:bHi] {
+			d.ops = append(d.ops, diffOp{'+', line})
+		}
+	case bLo == bHi:
+		for _, line := range d.a[aLo:aHi] {
+			d.ops = append(d.ops, diffOp{'-', line})
+		}
+	default:
+		// Without a common prefix or suffix there are at least two edits,
+		// so both halves are smaller than the whole
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.compare(aLo, x, bLo, y)
+		for _, line := range d.a[x:u] {
+			d.ops = append(d.ops, diffOp{' ', line})
+		}
+		d.compare(u, aHi, v, bHi)
+	}
+
+	for _, line := range d.a[aHi : aHi+suffix] {
+		d.ops = append(d.ops, diffOp{' ', line})
+	}
+}
+
+// middleSnake finds the middle snake of an optimal edit script from
+// a[aLo:aHi] to b[bLo:bHi] by searching from both ends at once. The snake
+// runs from (x, y) to (u, v) in absolute line indexes.
+func (d *differ) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+
+	size := 2*maxD + 3
+	if cap(d.forward) < size {
+		d.forward, d.reverse = make([]int, size), make([]int, size)
+	}
+	forward, reverse := d.forward[:size], d.reverse[:size]
+	forward[offset+1], reverse[offset+1] = 0, 0
+
+	for step := 0; step <= maxD; step++ {
+		for k := -step; k <= step; k += 2 {
+			var x int
+			if k == -step || (k != step && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && d.a[aLo+x] == d.b[bLo+y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+
+			if kr := delta - k; odd && kr >= -(step-1) && kr <= step-1 && x+reverse[offset+kr] >= n {
+				return aLo + startX, bLo + startY, aLo + x, bLo + y
+			}
+		}
+
+		for kr := -step; kr <= step; kr += 2 {
+			var x int
+			if kr == -step || (kr != step && reverse[offset+kr-1] < reverse[offset+kr+1]) {
+				x = reverse[offset+kr+1]
+			} else {
+				x = reverse[offset+kr-1] + 1
+			}
+			y := x - kr
+			startX, startY := x, y
+			for x < n && y < m && d.a[aHi-1-x] == d.b[bHi-1-y] {
+				x++
+				y++
+			}
+			reverse[offset+kr] = x
+
+			if k := delta - kr; !odd && k >= -step && k <= step && x+forward[offset+k] >= n {
+				return aHi - x, bHi - y, aHi - startX, bHi - startY
+			}
+		}
+	}
+
+	panic("middleSnake: searches did not meet")
+}
@@ -0,0 +1,130 @@
+package workflowdocgen
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{
+			name: "equal",
+			old:  "a\nb\n",
+			new:  "a\nb\n",
+			want: "",
+		},
+		{
+			name: "changed line",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n",
+			new:  "1\n2\n3\n4\nfive\n6\n7\n8\n",
+			want: "--- old\n+++ new\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "separate hunks",
+			old:  "a\n1\n2\n3\n4\n5\n6\n7\nb\n",
+			new:  "A\n1\n2\n3\n4\n5\n6\n7\nB\n",
+			want: "--- old\n+++ new\n@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n@@ -6,4 +6,4 @@\n 5\n 6\n 7\n-b\n+B\n",
+		},
+		{
+			name: "new file",
+			old:  "",
+			new:  "a\nb\n",
+			want: "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name: "missing trailing newline",
+			old:  "a\nb",
+			new:  "a\nb\n",
+			want: "--- old\n+++ new\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnifiedDiff("old", "new", tt.old, tt.new); got != tt.want {
+				t.Errorf("Expected diff:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	// lcsLength is the reference the edit scripts must be minimal against
+	lcsLength := func(a, b []string) int {
+		prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+		for i := range a {
+			for j := range b {
+				if a[i] == b[j] {
+					cur[j+1] = prev[j] + 1
+				} else {
+					cur[j+1] = max(prev[j+1], cur[j])
+				}
+			}
+			prev, cur = cur, prev
+		}
+		return prev[len(b)]
+	}
+
+	t.Run("minimal edit scripts", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(1, 2))
+		lines := func() []string {
+			result := make([]string, rng.IntN(12))
+			for i := range result {
+				result[i] = string(rune('a' + rng.IntN(4)))
+			}
+			return result
+		}
+
+		for range 2000 {
+			a, b := lines(), lines()
+			ops := diffLines(a, b)
+
+			var gotA, gotB []string
+			edits := 0
+			for _, op := range ops {
+				if op.kind != '+' {
+					gotA = append(gotA, op.text)
+				}
+				if op.kind != '-' {
+					gotB = append(gotB, op.text)
+				}
+				if op.kind != ' ' {
+					edits++
+				}
+			}
+			if !slices.Equal(gotA, a) || !slices.Equal(gotB, b) {
+				t.Fatalf("Edit script of %q to %q does not reproduce them: %v", a, b, ops)
+			}
+			if want := len(a) + len(b) - 2*lcsLength(a, b); edits != want {
+				t.Fatalf("Expected %d edits from %q to %q, got %d", want, a, b, edits)
+			}
+		}
+	})
+
+	t.Run("large documents", func(t *testing.T) {
+		var old, changed strings.Builder
+		for i := range 20000 {
+			line := fmt.Sprintf("| workflow-%d.yml | description %d |\n", i, i)
+			old.WriteString(line)
+			if i%5000 == 2500 {
+				line = "| changed |\n"
+			}
+			changed.WriteString(line)
+		}
+
+		diff := UnifiedDiff("old", "new", old.String(), changed.String())
+		if hunks := strings.Count(diff, "\n@@ "); hunks != 4 {
+			t.Errorf("Expected 4 hunks, got %d:\n%s", hunks, diff)
+		}
+		if !strings.Contains(diff, "@@ -2498,7 +2498,7 @@\n") {
+			t.Errorf("Expected a hunk around line 2501, got:\n%s", diff)
+		}
+	})
+}
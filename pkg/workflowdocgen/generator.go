@@ -1,97 +1,330 @@
 package workflowdocgen
 
 import (
+	_ "embed"
 	"fmt"
+	"io"
 	"os"
+	"reflect"
+	"slices"
 	"strings"
+	"text/template"
 )
 
+// DefaultMarkdownTemplate is the text/template source of the default markdown layout
+//
+//go:embed templates/markdown.md.tmpl
+var DefaultMarkdownTemplate string
+
+// defaultMarkdownTemplate is the parsed DefaultMarkdownTemplate
+var defaultMarkdownTemplate = template.Must(ParseMarkdownTemplate(DefaultMarkdownTemplate))
+
+// MarkdownTemplateData is the data a markdown template is executed with
+type MarkdownTemplateData struct {
+	Workflows  []*WorkflowDoc
+	Actions    []*ActionDoc
+	CallGraph  *CallGraph
+	CustomKeys []string
+	Columns    []string
+}
+
+// TableColumns lists the columns of the summary table in default order
+var TableColumns = []string{"workflow", "description", "owners", "tags", "triggers", "file"}
+
+// ValidateColumns reports an error for names that are not in TableColumns
+func ValidateColumns(columns []string) error {
+	for _, column := range columns {
+		if !slices.Contains(TableColumns, column) {
+			return fmt.Errorf("unknown column %q, expected one of: %s", column, strings.Join(TableColumns, ", "))
+		}
+	}
+	return nil
+}
+
+// markdownFuncs are the helper functions available to markdown templates
+var markdownFuncs = template.FuncMap{
+	"escapeMarkdown":    escapeMarkdown,
+	"join":              strings.Join,
+	"default":           defaultValue,
+	"inline":            inlineText,
+	"cell":              descriptionCell,
+	"code":              codeCell,
+	"codeList":          codeList,
+	"field":             fieldText,
+	"label":             customKeyLabel,
+	"mentions":          ownerMentions,
+	"badges":            tagBadges,
+	"hasDetails":        hasDetails,
+	"permissionsMatrix": permissionsMatrix,
+	"hangIndent":        hangIndent,
+	"columnTitle":       columnTitle,
+	"columnRule":        columnRule,
+	"columnCell":        columnCell,
+}
+
+// ParseMarkdownTemplate parses a text/template for the markdown output. The
+// template is executed with MarkdownTemplateData and can use the helper
+// functions of the default template, such as escapeMarkdown, join and default.
+func ParseMarkdownTemplate(text string) (*template.Template, error) {
+	return template.New("markdown").Funcs(markdownFuncs).Parse(text)
+}
+
 // GenerateMarkdownTable generates a markdown table from workflow documentation
 func GenerateMarkdownTable(docs []*WorkflowDoc, outputPath string) error {
+	return GenerateMarkdownTableWithOptions(docs, outputPath, RenderOptions{})
+}
+
+// GenerateMarkdownTableWithOptions generates a markdown table from workflow documentation using the given options
+func GenerateMarkdownTableWithOptions(docs []*WorkflowDoc, outputPath string, opts RenderOptions) error {
+	content, err := RenderMarkdownTable(docs, opts)
+	if err != nil {
+		return err
+	}
+
+	// Write to file with readable permissions for collaborative environments
+	// #nosec G306 - 0644 is intentional for collaborative environments
+	return os.WriteFile(outputPath, []byte(content), 0644)
+}
+
+// WriteMarkdownTable writes the markdown documentation to w
+func WriteMarkdownTable(w io.Writer, docs []*WorkflowDoc, opts RenderOptions) error {
+	tmpl := opts.Template
+	if tmpl == nil {
+		tmpl = defaultMarkdownTemplate
+	}
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = TableColumns
+	}
+	return tmpl.Execute(w, MarkdownTemplateData{
+		Workflows:  docs,
+		Actions:    opts.Actions,
+		CallGraph:  BuildCallGraph(docs),
+		CustomKeys: opts.CustomKeys,
+		Columns:    columns,
+	})
+}
+
+// RenderMarkdownTable renders the workflow documentation as markdown in memory
+func RenderMarkdownTable(docs []*WorkflowDoc, opts RenderOptions) (string, error) {
 	var sb strings.Builder
+	if err := WriteMarkdownTable(&sb, docs, opts); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
 
-	// Write the header
-	sb.WriteString("# Workflow Documentation\n\n")
-	sb.WriteString("This document provides an overview of all GitHub workflows in this repository.\n\n")
+// columnTitle returns the heading of a summary table column
+func columnTitle(column string) string {
+	if column == "" {
+		return column
+	}
+	return strings.ToUpper(column[:1]) + column[1:]
+}
 
-	// Write the table header
-	sb.WriteString("| Workflow | Description | Owners | Tags | File |\n")
-	sb.WriteString("|----------|-------------|--------|------|------|\n")
+// columnRule returns the separator below the heading of a summary table column
+func columnRule(column string) string {
+	return strings.Repeat("-", len(column)+2)
+}
 
-	// Write each workflow as a row
-	for _, doc := range docs {
-		name := doc.Name
-		if name == "" {
-			name = "-"
+// columnCell formats the value of a workflow for a summary table column
+func columnCell(doc *WorkflowDoc, column string) (string, error) {
+	switch column {
+	case "workflow":
+		return escapeMarkdown(inlineText(orDash(doc.DisplayName()))), nil
+	case "description":
+		return escapeMarkdown(inlineText(orDash(doc.Description))), nil
+	case "owners":
+		return ownerMentions(doc.OwnerHandles()), nil
+	case "tags":
+		return tagBadges(doc.TagNames()), nil
+	case "triggers":
+		// Prefer the declared events; fall back to the @workflow.triggers note
+		triggers := strings.Join(doc.TriggerEvents(), ", ")
+		if triggers == "" {
+			triggers = doc.TriggersNote
 		}
+		return escapeMarkdown(inlineText(orDash(triggers))), nil
+	case "file":
+		return doc.FileName, nil
+	}
+	return "", ValidateColumns([]string{column})
+}
 
-		description := doc.Description
-		if description == "" {
-			description = "-"
-		}
+// hasDetails reports whether a workflow has anything to show in the detail section
+func hasDetails(doc *WorkflowDoc, customKeys []string) bool {
+	return strings.Contains(doc.Description, "\n") ||
+		doc.Params != "" || doc.Results != "" || doc.Permissions != "" || doc.Requirements != "" ||
+		doc.TriggersNote != "" || len(doc.Triggers) > 0 || len(doc.Inputs) > 0 ||
+		len(doc.Outputs) > 0 || len(doc.Secrets) > 0 || len(doc.Jobs) > 0 ||
+		hasCustomFields(doc, customKeys)
+}
 
-		owners := doc.Owners
-		if owners == "" {
-			owners = "-"
+// defaultValue returns value, or def if value is empty
+func defaultValue(def, value any) any {
+	if value == nil {
+		return def
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		if v.Len() == 0 {
+			return def
+		}
+	default:
+		if v.IsZero() {
+			return def
 		}
+	}
+	return value
+}
 
-		tags := doc.Tags
-		if tags == "" {
-			tags = "-"
+// hasCustomFields reports whether doc has a value for any of the custom keys
+func hasCustomFields(doc *WorkflowDoc, keys []string) bool {
+	for _, key := range keys {
+		if doc.Extra[key] != "" {
+			return true
 		}
+	}
+	return false
+}
 
-		file := doc.FileName
+// customKeyLabel turns a custom key such as "slack-channel" into "Slack channel"
+func customKeyLabel(key string) string {
+	label := strings.NewReplacer("-", " ", "_", " ").Replace(key)
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// fieldText formats a labelled annotation value; multi-line values start a
+// new paragraph so that lists and paragraphs in them render as markdown
+func fieldText(label, value string) string {
+	if strings.Contains(value, "\n") {
+		return fmt.Sprintf("**%s:**\n\n%s\n\n", label, value)
+	}
+	return fmt.Sprintf("**%s:** %s\n\n", label, value)
+}
 
-		// Escape special markdown characters in content
-		name = escapeMarkdown(name)
-		description = escapeMarkdown(description)
-		owners = escapeMarkdown(owners)
-		tags = escapeMarkdown(tags)
+// inlineText collapses a multi-line value to a single line for a table cell
+func inlineText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
 
-		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
-			name, description, owners, tags, file))
+// descriptionCell formats a description for a table cell, or "-" if empty.
+// Block scalar descriptions span lines; they are joined to keep the row intact.
+func descriptionCell(s string) string {
+	s = inlineText(s)
+	if s == "" {
+		return "-"
 	}
+	return escapeMarkdown(s)
+}
 
-	// Always add detailed section, but only show workflows with extended metadata
-	sb.WriteString("\n## Detailed Workflow Information\n\n")
+// codeCell formats a value as inline code for a table cell, or "-" if empty.
+// Multi-line values are joined like descriptions, and the code span is
+// delimited by more backticks than the value contains in a row.
+func codeCell(s string) string {
+	s = inlineText(s)
+	if s == "" {
+		return "-"
+	}
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return fence + strings.ReplaceAll(s, "|", "\\|") + fence
+}
 
-	hasAnyDetails := false
-	for _, doc := range docs {
-		if doc.Params == "" && doc.Results == "" && doc.Permissions == "" && doc.Requirements == "" {
-			continue
-		}
+// codeList formats values as a comma-separated list of inline code, or "-" if empty
+func codeList(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	codes := make([]string, len(values))
+	for i, value := range values {
+		codes[i] = codeCell(value)
+	}
+	return strings.Join(codes, ", ")
+}
 
-		hasAnyDetails = true
-		workflowName := doc.Name
-		if workflowName == "" {
-			workflowName = doc.FileName
-		}
+// hangIndent indents every line of s but the first by width spaces, so that
+// multi-line text stays inside a list item
+func hangIndent(width int, s string) string {
+	return strings.ReplaceAll(s, "\n", "\n"+strings.Repeat(" ", width))
+}
 
-		sb.WriteString(fmt.Sprintf("### %s\n\n", workflowName))
+// orDash returns s, or "-" if s is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
 
-		if doc.Params != "" {
-			sb.WriteString(fmt.Sprintf("**Parameters:** %s\n\n", doc.Params))
-		}
+// permissionsMatrix formats the effective token permissions of every job, or
+// returns "" if the workflow has no jobs
+func permissionsMatrix(doc *WorkflowDoc) string {
+	if len(doc.Jobs) == 0 {
+		return ""
+	}
 
-		if doc.Results != "" {
-			sb.WriteString(fmt.Sprintf("**Results:** %s\n\n", doc.Results))
+	effective := make([]*PermissionSet, len(doc.Jobs))
+	allDefault := true
+	for i, job := range doc.Jobs {
+		effective[i] = doc.EffectivePermissions(job)
+		if effective[i].Source != PermissionSourceDefault {
+			allDefault = false
 		}
+	}
 
-		if doc.Permissions != "" {
-			sb.WriteString(fmt.Sprintf("**Permissions:** %s\n\n", doc.Permissions))
-		}
+	if allDefault {
+		return "**Effective permissions:** all jobs use the repository's default token permissions\n\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("**Effective permissions:**\n\n")
 
-		if doc.Requirements != "" {
-			sb.WriteString(fmt.Sprintf("**Requirements:** %s\n\n", doc.Requirements))
+	// Only show scopes that at least one job can access
+	var scopes []string
+	for _, scope := range PermissionScopes {
+		for _, set := range effective {
+			if access := set.Access(scope); access != "" && access != "none" {
+				scopes = append(scopes, scope)
+				break
+			}
 		}
 	}
 
-	// If no workflows had extended metadata, add a note
-	if !hasAnyDetails {
-		sb.WriteString("_No workflows have extended metadata configured._\n\n")
+	sb.WriteString("| Job | Source |")
+	for _, scope := range scopes {
+		sb.WriteString(fmt.Sprintf(" %s |", scope))
 	}
+	sb.WriteString("\n|-----|--------|")
+	for range scopes {
+		sb.WriteString("------|")
+	}
+	sb.WriteString("\n")
 
-	// Write to file with readable permissions for collaborative environments
-	// #nosec G306 - 0644 is intentional for collaborative environments
-	return os.WriteFile(outputPath, []byte(sb.String()), 0644)
+	for i, job := range doc.Jobs {
+		source := effective[i].Source
+		if effective[i].Preset != "" {
+			source = fmt.Sprintf("%s (%s)", source, effective[i].Preset)
+		}
+		sb.WriteString(fmt.Sprintf("| `%s` | %s |", job.ID, source))
+		for _, scope := range scopes {
+			access := effective[i].Access(scope)
+			if access == "" {
+				access = "default"
+			}
+			sb.WriteString(fmt.Sprintf(" %s |", access))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
 }
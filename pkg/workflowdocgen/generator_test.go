@@ -1,6 +1,9 @@
 package workflowdocgen
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -47,7 +50,7 @@ func TestGenerateMarkdownTable(t *testing.T) {
 		}
 
 		// Check table header
-		if !strings.Contains(output, "| Workflow | Description | Owners | Tags | File |") {
+		if !strings.Contains(output, "| Workflow | Description | Owners | Tags | Triggers | File |") {
 			t.Error("Expected table header not found")
 		}
 
@@ -61,8 +64,8 @@ func TestGenerateMarkdownTable(t *testing.T) {
 		if !strings.Contains(output, "team-platform") {
 			t.Error("Expected 'team-platform' in output")
 		}
-		if !strings.Contains(output, "ci, automation") {
-			t.Error("Expected 'ci, automation' in output")
+		if !strings.Contains(output, "![ci](https://img.shields.io/badge/ci-blue) ![automation](https://img.shields.io/badge/automation-blue)") {
+			t.Error("Expected badges for 'ci, automation' in output")
 		}
 
 		if !strings.Contains(output, "Deploy") {
@@ -96,7 +99,7 @@ func TestGenerateMarkdownTable(t *testing.T) {
 		output := string(content)
 
 		// Should use "-" for empty fields
-		if !strings.Contains(output, "| Test Workflow | - | - | - | test.yml |") {
+		if !strings.Contains(output, "| Test Workflow | - | - | - | - | test.yml |") {
 			t.Errorf("Expected row with '-' for empty fields, got:\n%s", output)
 		}
 	})
@@ -221,7 +224,7 @@ func TestGenerateMarkdownTable(t *testing.T) {
 		if !strings.Contains(output, "# Workflow Documentation") {
 			t.Error("Expected header even with no workflows")
 		}
-		if !strings.Contains(output, "| Workflow | Description | Owners | Tags | File |") {
+		if !strings.Contains(output, "| Workflow | Description | Owners | Tags | Triggers | File |") {
 			t.Error("Expected table header even with no workflows")
 		}
 		// Should have detailed section with message
@@ -303,6 +306,349 @@ func TestGenerateMarkdownTable(t *testing.T) {
 		}
 	})
 
+	t.Run("workflow name fallback to declared name", func(t *testing.T) {
+		docs := []*WorkflowDoc{
+			{
+				DeclaredName: "Release",
+				NameSource:   NameSourceDeclared,
+				FileName:     "release.yml",
+				Params:       "version",
+			},
+		}
+
+		outputPath := filepath.Join(tempDir, "output11.md")
+		err := GenerateMarkdownTable(docs, outputPath)
+		if err != nil {
+			t.Fatalf("GenerateMarkdownTable failed: %v", err)
+		}
+
+		content, err := os.ReadFile(outputPath) // #nosec G304 - test file in temp directory
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+
+		output := string(content)
+
+		if !strings.Contains(output, "| Release | - | - | - | - | release.yml |") {
+			t.Errorf("Expected declared name in table row, got:\n%s", output)
+		}
+		if !strings.Contains(output, "### Release") {
+			t.Error("Expected declared name as heading")
+		}
+	})
+
+	t.Run("workflow with triggers", func(t *testing.T) {
+		docs := []*WorkflowDoc{
+			{
+				Name:         "Nightly",
+				FileName:     "nightly.yml",
+				TriggersNote: "nightly and on demand",
+				Triggers: []Trigger{
+					{Event: "push", Branches: []string{"main"}, Paths: []string{"**.go", "go.mod"}},
+					{Event: "schedule", Cron: "0 3 * * *"},
+					{Event: "schedule", Cron: "0 12 * * 1"},
+					{Event: "workflow_dispatch"},
+					{Event: "repository_dispatch", Types: []string{"deploy"}},
+					{Event: "merge_group"},
+				},
+			},
+			{
+				Name:         "Annotated",
+				FileName:     "annotated.yml",
+				TriggersNote: "push to main",
+			},
+		}
+
+		outputPath := filepath.Join(tempDir, "output12.md")
+		err := GenerateMarkdownTable(docs, outputPath)
+		if err != nil {
+			t.Fatalf("GenerateMarkdownTable failed: %v", err)
+		}
+
+		content, err := os.ReadFile(outputPath) // #nosec G304 - test file in temp directory
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+
+		output := string(content)
+
+		expected := []string{
+			"| Nightly | - | - | - | push, schedule, workflow\\_dispatch, repository\\_dispatch, merge\\_group | nightly.yml |",
+			"| Annotated | - | - | - | push to main | annotated.yml |",
+			"**Triggers:** nightly and on demand\n\n",
+			"- `push`: branches: `main`; paths: `**.go`, `go.mod`\n",
+			"- `schedule`: At 03:00 UTC every day (`0 3 * * *`)\n",
+			"- `schedule`: At 12:00 UTC on Monday (`0 12 * * 1`)\n",
+			"- `workflow_dispatch`: manual run\n",
+			"- `repository_dispatch`: types: `deploy`\n",
+			"- `merge_group`\n",
+			"### Annotated\n\n**Triggers:** push to main\n\n",
+		}
+		for _, want := range expected {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %q in output, got:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("workflow with declared inputs", func(t *testing.T) {
+		docs := []*WorkflowDoc{
+			{
+				Name:     "Deploy",
+				FileName: "deploy.yml",
+				Params:   "see inputs",
+				Inputs: []Param{
+					{Name: "environment", Type: "choice", Required: true, Default: "staging", Options: []string{"staging", "production"}, Description: "Target | environment"},
+					{Name: "notes", Type: "string", Description: "Multi\nline"},
+				},
+			},
+		}
+
+		outputPath := filepath.Join(tempDir, "output13.md")
+		err := GenerateMarkdownTable(docs, outputPath)
+		if err != nil {
+			t.Fatalf("GenerateMarkdownTable failed: %v", err)
+		}
+
+		content, err := os.ReadFile(outputPath) // #nosec G304 - test file in temp directory
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+
+		output := string(content)
+
+		expected := []string{
+			"**Parameters:** see inputs\n\n**Inputs:**\n\n",
+			"| Name | Type | Required | Default | Allowed values | Description |\n",
+			"| `environment` | choice | yes | `staging` | `staging`, `production` | Target \\| environment |\n",
+			"| `notes` | string | no | - | - | Multi line |\n",
+		}
+		for _, want := range expected {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %q in output, got:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("reusable workflow with outputs and secrets", func(t *testing.T) {
+		docs := []*WorkflowDoc{
+			{
+				Name:     "Build",
+				FileName: "build.yml",
+				Triggers: []Trigger{{Event: "workflow_call"}},
+				Inputs:   []Param{{Name: "target", Type: "string", Required: true, Events: []string{"workflow_call"}}},
+				Outputs:  []Output{{Name: "artifact", Description: "Artifact name", Value: "${{ jobs.build.outputs.artifact }}"}},
+				Secrets:  []Secret{{Name: "token", Required: true}},
+			},
+		}
+
+		outputPath := filepath.Join(tempDir, "output14.md")
+		err := GenerateMarkdownTable(docs, outputPath)
+		if err != nil {
+			t.Fatalf("GenerateMarkdownTable failed: %v", err)
+		}
+
+		content, err := os.ReadFile(outputPath) // #nosec G304 - test file in temp directory
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+
+		output := string(content)
+
+		expected := []string{
+			"**Outputs:**\n\n| Name | Description | Value |\n",
+			"| `artifact` | Artifact name | `${{ jobs.build.outputs.artifact }}` |\n",
+			"**Secrets:**\n\n| Name | Required | Description |\n",
+			"| `token` | yes | - |\n",
+			"**Usage:**\n\n```yaml\njobs:\n  build:\n    uses: <owner>/<repo>/.github/workflows/build.yml@<ref>\n",
+			"      target: \"\" # required, string\n",
+			"      token: ${{ secrets.token }} # required\n```\n",
+		}
+		for _, want := range expected {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %q in output, got:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("permissions matrix", func(t *testing.T) {
+		docs := []*WorkflowDoc{
+			{
+				Name:                "Release",
+				FileName:            "release.yml",
+				DeclaredPermissions: &PermissionSet{Source: PermissionSourceWorkflow, Scopes: map[string]string{"contents": "read"}},
+				Jobs: []*JobDoc{
+					{ID: "build"},
+					{ID: "publish", DeclaredPermissions: &PermissionSet{Source: PermissionSourceJob, Scopes: map[string]string{"contents": "write", "id-token": "write"}}},
+				},
+			},
+			{
+				Name:     "Defaults",
+				FileName: "defaults.yml",
+				Jobs:     []*JobDoc{{ID: "test"}},
+			},
+		}
+
+		outputPath := filepath.Join(tempDir, "output15.md")
+		err := GenerateMarkdownTable(docs, outputPath)
+		if err != nil {
+			t.Fatalf("GenerateMarkdownTable failed: %v", err)
+		}
+
+		content, err := os.ReadFile(outputPath) // #nosec G304 - test file in temp directory
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+
+		output := string(content)
+
+		expected := []string{
+			"| Job | Source | contents | id-token |\n",
+			"| `build` | workflow | read | none |\n",
+			"| `publish` | job | write | write |\n",
+			"**Effective permissions:** all jobs use the repository's default token permissions\n",
+		}
+		for _, want := range expected {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %q in output, got:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("custom keys from the allow-list", func(t *testing.T) {
+		docs := []*WorkflowDoc{
+			{
+				Name:     "CI",
+				FileName: "ci.yml",
+				Extra:    map[string]string{"slack-channel": "#ci-alerts", "cost_center": "1234"},
+			},
+		}
+
+		outputPath := filepath.Join(tempDir, "output16.md")
+		err := GenerateMarkdownTableWithOptions(docs, outputPath, RenderOptions{CustomKeys: []string{"slack-channel", "runbook"}})
+		if err != nil {
+			t.Fatalf("GenerateMarkdownTableWithOptions failed: %v", err)
+		}
+
+		content, err := os.ReadFile(outputPath) // #nosec G304 - test file in temp directory
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+
+		output := string(content)
+
+		if !strings.Contains(output, "### CI\n\n**Slack channel:** #ci-alerts\n\n") {
+			t.Errorf("Expected allowed custom key in detailed section, got:\n%s", output)
+		}
+		if strings.Contains(output, "1234") {
+			t.Error("Custom keys outside the allow-list should not be rendered")
+		}
+		if strings.Contains(output, "Runbook") {
+			t.Error("Allowed custom keys without a value should not be rendered")
+		}
+	})
+
+	t.Run("multi-line values", func(t *testing.T) {
+		docs := []*WorkflowDoc{
+			{
+				Name:         "CI",
+				Description:  "Builds the project.\n\n- lint\n- test",
+				Requirements: "Secrets:\n- TOKEN",
+				FileName:     "ci.yml",
+				Jobs: []*JobDoc{
+					{ID: "test", Steps: []*StepDoc{{Index: 1, Name: "Test", Description: "Run tests\nwith coverage"}}},
+				},
+			},
+		}
+
+		outputPath := filepath.Join(tempDir, "output17.md")
+		err := GenerateMarkdownTable(docs, outputPath)
+		if err != nil {
+			t.Fatalf("GenerateMarkdownTable failed: %v", err)
+		}
+
+		content, err := os.ReadFile(outputPath) // #nosec G304 - test file in temp directory
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+
+		output := string(content)
+
+		expected := []string{
+			"| CI | Builds the project. - lint - test | - | - | - | ci.yml |\n",
+			"### CI\n\nBuilds the project.\n\n- lint\n- test\n\n",
+			"**Requirements:**\n\nSecrets:\n- TOKEN\n\n",
+			"1. **Test** - Run tests\n   with coverage\n",
+		}
+		for _, want := range expected {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %q in output, got:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("owners and tags", func(t *testing.T) {
+		docs := []*WorkflowDoc{
+			{
+				Name:      "Release",
+				Owners:    "@Acme/Release-Team, platform",
+				OwnerList: []string{"acme/release-team", "platform"},
+				TagList:   []string{"release", "go_lang", "multi word"},
+				FileName:  "release.yml",
+			},
+		}
+
+		outputPath := filepath.Join(tempDir, "output18.md")
+		err := GenerateMarkdownTableWithOptions(docs, outputPath, RenderOptions{})
+		if err != nil {
+			t.Fatalf("GenerateMarkdownTableWithOptions failed: %v", err)
+		}
+
+		content, err := os.ReadFile(outputPath) // #nosec G304 - test file in temp directory
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+
+		output := string(content)
+
+		expected := []string{
+			"[@acme/release-team](https://github.com/orgs/acme/teams/release-team), [@platform](https://github.com/platform)",
+			"![release](https://img.shields.io/badge/release-blue)",
+			"![go_lang](https://img.shields.io/badge/go__lang-blue)",
+			"![multi word](https://img.shields.io/badge/multi%20word-blue)",
+		}
+		for _, want := range expected {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %q in output, got:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("rendered markdown matches written file", func(t *testing.T) {
+		docs := []*WorkflowDoc{
+			{Name: "CI", Description: "Build", FileName: "ci.yml", Triggers: []Trigger{{Event: "push"}}},
+		}
+
+		outputPath := filepath.Join(tempDir, "output19.md")
+		if err := GenerateMarkdownTable(docs, outputPath); err != nil {
+			t.Fatalf("GenerateMarkdownTable failed: %v", err)
+		}
+
+		content, err := os.ReadFile(outputPath) // #nosec G304 - test file in temp directory
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+
+		rendered, err := RenderMarkdownTable(docs, RenderOptions{})
+		if err != nil {
+			t.Fatalf("RenderMarkdownTable failed: %v", err)
+		}
+		if rendered != string(content) {
+			t.Errorf("Expected rendered markdown to match file content, got diff:\n%s",
+				UnifiedDiff("file", "rendered", string(content), rendered))
+		}
+	})
+
 	t.Run("file write error - invalid path", func(t *testing.T) {
 		docs := []*WorkflowDoc{
 			{
@@ -350,4 +696,268 @@ func TestGenerateMarkdownTable(t *testing.T) {
 			t.Error("Expected filename as heading when name is empty")
 		}
 	})
+
+	t.Run("workflow with job documentation", func(t *testing.T) {
+		docs := []*WorkflowDoc{
+			{
+				Name:     "CI",
+				FileName: "ci.yml",
+				Jobs: []*JobDoc{
+					{ID: "test", Description: "Run unit tests", Owners: "team-qa"},
+					{ID: "build", Name: "Build binaries", Requirements: "Go toolchain", RunsOn: "ubuntu-latest", Needs: []string{"test", "lint"}},
+				},
+			},
+		}
+
+		outputPath := filepath.Join(tempDir, "output9.md")
+		err := GenerateMarkdownTable(docs, outputPath)
+		if err != nil {
+			t.Fatalf("GenerateMarkdownTable failed: %v", err)
+		}
+
+		content, err := os.ReadFile(outputPath) // #nosec G304 - test file in temp directory
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+
+		output := string(content)
+
+		if !strings.Contains(output, "### CI") {
+			t.Error("Expected workflow with jobs in detailed section")
+		}
+		if !strings.Contains(output, "#### Job: `test`\n\nRun unit tests\n\n**Owners:** [@team-qa](https://github.com/team-qa)") {
+			t.Errorf("Expected section for job 'test', got:\n%s", output)
+		}
+		if !strings.Contains(output, "#### Job: Build binaries (`build`)") {
+			t.Error("Expected job heading with name and ID")
+		}
+		if !strings.Contains(output, "**Requirements:** Go toolchain") {
+			t.Error("Expected job requirements in output")
+		}
+		if !strings.Contains(output, "**Runs on:** `ubuntu-latest`") {
+			t.Error("Expected job runner in output")
+		}
+		if !strings.Contains(output, "**Needs:** `test`, `lint`") {
+			t.Error("Expected job dependencies in output")
+		}
+		if strings.Contains(output, "_No workflows have extended metadata configured._") {
+			t.Error("Did not expect 'no metadata' message when jobs are documented")
+		}
+	})
+
+	t.Run("job with collapsible step list", func(t *testing.T) {
+		docs := []*WorkflowDoc{
+			{
+				Name:     "Release",
+				FileName: "release.yml",
+				Jobs: []*JobDoc{
+					{
+						ID: "release",
+						Steps: []*StepDoc{
+							{Index: 1, Name: "Checkout code", Description: "Checkout the repository code"},
+							{Index: 2, Uses: "goreleaser/goreleaser-action@v6"},
+						},
+					},
+					{ID: "noop"},
+				},
+			},
+		}
+
+		outputPath := filepath.Join(tempDir, "output10.md")
+		err := GenerateMarkdownTable(docs, outputPath)
+		if err != nil {
+			t.Fatalf("GenerateMarkdownTable failed: %v", err)
+		}
+
+		content, err := os.ReadFile(outputPath) // #nosec G304 - test file in temp directory
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+
+		output := string(content)
+
+		if !strings.Contains(output, "<details>\n<summary>Steps (2)</summary>") {
+			t.Errorf("Expected collapsible step list, got:\n%s", output)
+		}
+		if !strings.Contains(output, "1. **Checkout code** - Checkout the repository code") {
+			t.Error("Expected first step with description")
+		}
+		if !strings.Contains(output, "2. **goreleaser/goreleaser-action@v6**\n") {
+			t.Error("Expected second step labelled by uses")
+		}
+		if strings.Count(output, "<details>") != 1 {
+			t.Error("Expected no step list for job without steps")
+		}
+	})
+}
+
+// failingWriter is an io.Writer that always fails
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriteMarkdownTable(t *testing.T) {
+	docs := []*WorkflowDoc{
+		{Name: "CI", Description: "Build", FileName: "ci.yml"},
+	}
+
+	t.Run("writes rendered markdown", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteMarkdownTable(&buf, docs, RenderOptions{}); err != nil {
+			t.Fatalf("WriteMarkdownTable failed: %v", err)
+		}
+		rendered, err := RenderMarkdownTable(docs, RenderOptions{})
+		if err != nil {
+			t.Fatalf("RenderMarkdownTable failed: %v", err)
+		}
+		if buf.String() != rendered {
+			t.Errorf("Expected written markdown to match rendered markdown, got:\n%s", buf.String())
+		}
+		if !strings.Contains(buf.String(), "| CI | Build | - | - | - | ci.yml |") {
+			t.Errorf("Expected workflow row in output, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("returns writer errors", func(t *testing.T) {
+		if err := WriteMarkdownTable(failingWriter{}, docs, RenderOptions{}); err == nil {
+			t.Error("Expected error from failing writer, got nil")
+		}
+	})
+
+	t.Run("selected columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteMarkdownTable(&buf, docs, RenderOptions{Columns: []string{"workflow", "file"}}); err != nil {
+			t.Fatalf("WriteMarkdownTable failed: %v", err)
+		}
+		for _, want := range []string{"| Workflow | File |\n", "|----------|------|\n", "| CI | ci.yml |\n"} {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("Expected output to contain %q, got:\n%s", want, buf.String())
+			}
+		}
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		if err := ValidateColumns([]string{"workflow", "size"}); err == nil {
+			t.Error("Expected error for unknown column, got nil")
+		}
+		if err := WriteMarkdownTable(&bytes.Buffer{}, docs, RenderOptions{Columns: []string{"size"}}); err == nil {
+			t.Error("Expected error for unknown column, got nil")
+		}
+	})
+}
+
+func TestMarkdownTemplate(t *testing.T) {
+	docs := []*WorkflowDoc{
+		{Name: "CI | main", Description: "Build\nand test", TagList: []string{"ci", "go"}, FileName: "ci.yml"},
+		{FileName: "bare.yml"},
+	}
+
+	t.Run("custom template", func(t *testing.T) {
+		tmpl, err := ParseMarkdownTemplate(`# Workflows
+{{range .Workflows}}
+- {{escapeMarkdown (default .FileName .DisplayName)}}: {{inline (default "undocumented" .Description)}} [{{join .TagNames ", "}}]
+{{- end}}
+`)
+		if err != nil {
+			t.Fatalf("ParseMarkdownTemplate failed: %v", err)
+		}
+
+		output, err := RenderMarkdownTable(docs, RenderOptions{Template: tmpl})
+		if err != nil {
+			t.Fatalf("RenderMarkdownTable failed: %v", err)
+		}
+
+		expected := "# Workflows\n\n- CI \\| main: Build and test [ci, go]\n- bare.yml: undocumented []\n"
+		if output != expected {
+			t.Errorf("Expected output %q, got %q", expected, output)
+		}
+	})
+
+	t.Run("default template matches exported source", func(t *testing.T) {
+		tmpl, err := ParseMarkdownTemplate(DefaultMarkdownTemplate)
+		if err != nil {
+			t.Fatalf("ParseMarkdownTemplate failed: %v", err)
+		}
+
+		custom, err := RenderMarkdownTable(docs, RenderOptions{Template: tmpl})
+		if err != nil {
+			t.Fatalf("RenderMarkdownTable failed: %v", err)
+		}
+		builtin, err := RenderMarkdownTable(docs, RenderOptions{})
+		if err != nil {
+			t.Fatalf("RenderMarkdownTable failed: %v", err)
+		}
+		if custom != builtin {
+			t.Errorf("Expected identical output, got diff:\n%s", UnifiedDiff("builtin", "custom", builtin, custom))
+		}
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		if _, err := ParseMarkdownTemplate("{{if}}"); err == nil {
+			t.Error("Expected parse error, got nil")
+		}
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		tmpl, err := ParseMarkdownTemplate("{{.Missing}}")
+		if err != nil {
+			t.Fatalf("ParseMarkdownTemplate failed: %v", err)
+		}
+		if _, err := RenderMarkdownTable(docs, RenderOptions{Template: tmpl}); err == nil {
+			t.Error("Expected execution error, got nil")
+		}
+		if err := GenerateMarkdownTableWithOptions(docs, filepath.Join(t.TempDir(), "out.md"), RenderOptions{Template: tmpl}); err == nil {
+			t.Error("Expected error from GenerateMarkdownTableWithOptions, got nil")
+		}
+	})
+}
+
+func TestDefaultValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  any
+	}{
+		{"nil", nil, "-"},
+		{"empty string", "", "-"},
+		{"string", "x", "x"},
+		{"empty slice", []string{}, "-"},
+		{"slice", []string{"a"}, []string{"a"}},
+		{"zero int", 0, "-"},
+		{"int", 3, 3},
+		{"false", false, "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultValue("-", tt.value); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Expected '%v', got '%v'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCodeCell(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"empty", "", "-"},
+		{"plain", "ubuntu-latest", "`ubuntu-latest`"},
+		{"pipe", "a|b", "`a\\|b`"},
+		{"multi-line", "a\nb\n", "`a b`"},
+		{"backtick", "echo `date`", "`` echo `date` ``"},
+		{"backtick run", "a``b", "```a``b```"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := codeCell(tt.value); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
 }
@@ -0,0 +1,21 @@
+package workflowdocgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestFile creates a workflow, action or other file below dir, and any
+// missing parent directories, and returns its path
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	filePath := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0750); err != nil { // #nosec G301 - test directory
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	return filePath
+}
@@ -0,0 +1,161 @@
+package workflowdocgen
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+//go:embed templates/page.html.tmpl
+var htmlTemplates embed.FS
+
+// htmlPageTemplate is the self-contained page rendered by HTMLRenderer
+var htmlPageTemplate = template.Must(template.New("page.html.tmpl").Funcs(template.FuncMap{
+	"join":  strings.Join,
+	"code":  codeSpans,
+	"label": customKeyLabel,
+}).ParseFS(htmlTemplates, "templates/page.html.tmpl"))
+
+// anchorPattern matches runs of characters that are not allowed in anchors
+var anchorPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// HTMLRenderer renders a self-contained static page with client-side search
+// and tag and owner filters. The page does not load any external resources.
+// The custom keys and actions of Options are rendered like in markdown.
+type HTMLRenderer struct {
+	Options RenderOptions
+}
+
+// htmlPage is the data of the page template
+type htmlPage struct {
+	Tags       []string
+	Owners     []string
+	Workflows  []htmlWorkflow
+	Actions    []htmlAction
+	CustomKeys []string
+}
+
+// htmlWorkflow is a workflow section of the page
+type htmlWorkflow struct {
+	Doc        *WorkflowDoc
+	Title      string
+	Anchor     string
+	Owners     []string
+	OwnerLinks []htmlLink
+	Tags       []string
+	// Search is the lowercased text matched by the search box
+	Search string
+}
+
+// htmlAction is an action section of the page
+type htmlAction struct {
+	Doc        *ActionDoc
+	Anchor     string
+	OwnerLinks []htmlLink
+}
+
+// htmlLink is a link with a display name; URL is empty for plain text
+type htmlLink struct {
+	Name string
+	URL  string
+}
+
+// Render writes the HTML page to w
+func (r HTMLRenderer) Render(w io.Writer, docs []*WorkflowDoc) error {
+	page := htmlPage{CustomKeys: r.Options.CustomKeys}
+	anchors := make(map[string]bool)
+
+	for _, doc := range docs {
+		workflow := htmlWorkflow{
+			Doc:    doc,
+			Title:  doc.DisplayName(),
+			Anchor: uniqueAnchor(anchors, doc.FileName),
+			Owners: doc.OwnerHandles(),
+			Tags:   doc.TagNames(),
+		}
+		if workflow.Title == "" {
+			workflow.Title = doc.FileName
+		}
+		workflow.OwnerLinks = htmlOwnerLinks(workflow.Owners)
+		workflow.Search = htmlSearchText(doc, workflow)
+
+		page.Tags = append(page.Tags, workflow.Tags...)
+		page.Owners = append(page.Owners, workflow.Owners...)
+		page.Workflows = append(page.Workflows, workflow)
+	}
+
+	for _, action := range r.Options.Actions {
+		page.Actions = append(page.Actions, htmlAction{
+			Doc:        action,
+			Anchor:     uniqueAnchor(anchors, "action-"+action.DisplayName()),
+			OwnerLinks: htmlOwnerLinks(action.OwnerHandles()),
+		})
+	}
+
+	slices.Sort(page.Tags)
+	page.Tags = slices.Compact(page.Tags)
+	slices.Sort(page.Owners)
+	page.Owners = slices.Compact(page.Owners)
+
+	return htmlPageTemplate.Execute(w, page)
+}
+
+// htmlOwnerLinks links owners to their GitHub team or profile page
+func htmlOwnerLinks(owners []string) []htmlLink {
+	links := make([]htmlLink, len(owners))
+	for i, owner := range owners {
+		links[i] = htmlLink{Name: owner, URL: ownerURL(owner)}
+	}
+	return links
+}
+
+// uniqueAnchor derives an anchor from a file name that is not in used yet
+func uniqueAnchor(used map[string]bool, fileName string) string {
+	base := strings.Trim(anchorPattern.ReplaceAllString(strings.ToLower(fileName), "-"), "-")
+	if base == "" {
+		base = "workflow"
+	}
+	anchor := base
+	for i := 2; used[anchor]; i++ {
+		anchor = fmt.Sprintf("%s-%d", base, i)
+	}
+	used[anchor] = true
+	return anchor
+}
+
+// htmlSearchText collects the text of a workflow that the search box matches
+func htmlSearchText(doc *WorkflowDoc, workflow htmlWorkflow) string {
+	parts := []string{workflow.Title, doc.FileName, doc.Description}
+	parts = append(parts, workflow.Owners...)
+	parts = append(parts, workflow.Tags...)
+	parts = append(parts, doc.TriggerEvents()...)
+	for _, input := range doc.Inputs {
+		parts = append(parts, input.Name)
+	}
+	for _, job := range doc.Jobs {
+		parts = append(parts, job.ID, job.Name, job.Description)
+		for _, step := range job.Steps {
+			parts = append(parts, step.Label(), step.Description)
+		}
+	}
+	return strings.ToLower(inlineText(strings.Join(parts, " ")))
+}
+
+// codeSpans escapes text for HTML and turns markdown `code` spans, as used in
+// trigger descriptions, into <code> elements
+func codeSpans(text string) template.HTML {
+	var sb strings.Builder
+	for i, part := range strings.Split(text, "`") {
+		if i%2 == 1 {
+			sb.WriteString("<code>" + template.HTMLEscapeString(part) + "</code>")
+		} else {
+			sb.WriteString(template.HTMLEscapeString(part))
+		}
+	}
+	// #nosec G203 - every part is escaped above
+	return template.HTML(sb.String())
+}
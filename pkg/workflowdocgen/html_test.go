@@ -0,0 +1,126 @@
+package workflowdocgen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHTMLRenderer(t *testing.T) {
+	docs := []*WorkflowDoc{
+		{
+			Name:        "CI <main>",
+			Description: "Build & test",
+			OwnerList:   []string{"platform", "acme/qa"},
+			TagList:     []string{"ci", "multi word"},
+			FileName:    "ci.yml",
+			Triggers:    []Trigger{{Event: "push", Branches: []string{"main"}}},
+			Inputs:      []Param{{Name: "version", Type: "string", Required: true, Events: []string{"workflow_call"}}},
+			Jobs: []*JobDoc{
+				{ID: "build", RunsOn: "ubuntu-latest", Steps: []*StepDoc{{Index: 1, Name: "Compile", Description: "Run go build"}}},
+			},
+		},
+		{FileName: "ci.yaml", TagList: []string{"release"}},
+	}
+
+	var buf bytes.Buffer
+	if err := (HTMLRenderer{}).Render(&buf, docs); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	output := buf.String()
+
+	expected := []string{
+		"<!DOCTYPE html>",
+		`<input id="search" type="search"`,
+		`<option value="ci">ci</option>`,
+		`<option value="multi word">multi word</option>`,
+		`<option value="release">release</option>`,
+		`<option value="acme/qa">@acme/qa</option>`,
+		`<section class="workflow" id="ci-yml" data-tags="ci,multi word" data-owners="platform,acme/qa"`,
+		`<h2>CI &lt;main&gt;<a class="anchor" href="#ci-yml"`,
+		`<a href="https://github.com/platform">@platform</a>`,
+		`<a href="https://github.com/orgs/acme/teams/qa">@acme/qa</a>`,
+		`<p class="description">Build &amp; test</p>`,
+		`<li><code>push</code>: branches: <code>main</code></li>`,
+		`<tr><td><code>version</code></td><td>string</td><td>yes</td>`,
+		`<li><strong>Compile</strong> - Run go build</li>`,
+		`<section class="workflow" id="ci-yaml"`,
+		`<h2>ci.yaml<a class="anchor"`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output", want)
+		}
+	}
+
+	if strings.Contains(output, "http://") || strings.Contains(output, "<link") || strings.Contains(output, "<script src") {
+		t.Error("Expected a self-contained page without external resources")
+	}
+}
+
+func TestHTMLRendererOptions(t *testing.T) {
+	action, err := ParseActionFile(writeTestFile(t, t.TempDir(), "setup-go/action.yml", setupGoAction))
+	if err != nil {
+		t.Fatalf("ParseActionFile failed: %v", err)
+	}
+	action.Dir = ".github/actions/setup-go"
+
+	docs := []*WorkflowDoc{{
+		FileName:    "ci.yml",
+		Permissions: "Needs contents: write",
+		Extra:       map[string]string{"runbook": "docs/ci.md", "ignored": "x"},
+	}}
+	renderer, err := NewRenderer("html", RenderOptions{CustomKeys: []string{"runbook"}, Actions: []*ActionDoc{action}})
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, docs); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	output := buf.String()
+
+	expected := []string{
+		"<h3>Permissions</h3>\n<p class=\"description\">Needs contents: write</p>",
+		"<h3>Runbook</h3>\n<p class=\"description\">docs/ci.md</p>",
+		`<section class="action" id="action-setup-go">`,
+		`<p class="meta"><code>./.github/actions/setup-go</code> · runs <code>composite</code>`,
+		"<pre>- uses: ./.github/actions/setup-go\n",
+		`<tr><td><code>go-version</code></td><td>yes</td>`,
+		"<h3>Runbook</h3>\n<p class=\"description\">docs/setup-go.md</p>",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Ignored") {
+		t.Error("Expected custom keys that are not configured to be left out")
+	}
+}
+
+func TestUniqueAnchor(t *testing.T) {
+	used := make(map[string]bool)
+	tests := []struct {
+		fileName string
+		want     string
+	}{
+		{"CI.yml", "ci-yml"},
+		{"ci.yml", "ci-yml-2"},
+		{"ci.yml", "ci-yml-3"},
+		{"___", "workflow"},
+	}
+	for _, tt := range tests {
+		if got := uniqueAnchor(used, tt.fileName); got != tt.want {
+			t.Errorf("Expected anchor '%s' for '%s', got '%s'", tt.want, tt.fileName, got)
+		}
+	}
+}
+
+func TestCodeSpans(t *testing.T) {
+	got := string(codeSpans("paths: `a<b`, `c` & more"))
+	want := "paths: <code>a&lt;b</code>, <code>c</code> &amp; more"
+	if got != want {
+		t.Errorf("Expected '%s', got '%s'", want, got)
+	}
+}
@@ -0,0 +1,65 @@
+package workflowdocgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markers that delimit the generated section of a file for InjectBetweenMarkers
+const (
+	StartMarker = "<!-- workflowdocgen:start -->"
+	EndMarker   = "<!-- workflowdocgen:end -->"
+)
+
+// InjectBetweenMarkers replaces the text between StartMarker and EndMarker in
+// content with generated and leaves everything else untouched. Each marker
+// must appear exactly once, the start marker first.
+func InjectBetweenMarkers(content, generated string) (string, error) {
+	start, err := findMarker(content, StartMarker)
+	if err != nil {
+		return "", err
+	}
+	end, err := findMarker(content, EndMarker)
+	if err != nil {
+		return "", err
+	}
+	if end < start {
+		return "", fmt.Errorf("end marker %s on line %d comes before start marker %s on line %d",
+			EndMarker, lineOf(content, end), StartMarker, lineOf(content, start))
+	}
+
+	// Keep the indentation of an end marker that starts its own line
+	if lineStart := strings.LastIndex(content[:end], "\n") + 1; lineStart > start && strings.TrimSpace(content[lineStart:end]) == "" {
+		end = lineStart
+	}
+
+	if generated != "" && !strings.HasSuffix(generated, "\n") {
+		generated += "\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(content[:start+len(StartMarker)])
+	sb.WriteString("\n")
+	sb.WriteString(generated)
+	sb.WriteString(content[end:])
+	return sb.String(), nil
+}
+
+// findMarker returns the offset of the only occurrence of marker in content
+func findMarker(content, marker string) (int, error) {
+	first := strings.Index(content, marker)
+	if first < 0 {
+		return 0, fmt.Errorf("marker %s not found", marker)
+	}
+	if second := strings.Index(content[first+len(marker):], marker); second >= 0 {
+		second += first + len(marker)
+		return 0, fmt.Errorf("marker %s found more than once, on lines %d and %d",
+			marker, lineOf(content, first), lineOf(content, second))
+	}
+	return first, nil
+}
+
+// lineOf returns the 1-based line number of an offset in content
+func lineOf(content string, offset int) int {
+	return strings.Count(content[:offset], "\n") + 1
+}
@@ -0,0 +1,73 @@
+package workflowdocgen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInjectBetweenMarkers(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		generated string
+		want      string
+		wantErr   string
+	}{
+		{
+			name:      "replaces section",
+			content:   "# Readme\n\n<!-- workflowdocgen:start -->\nold\n<!-- workflowdocgen:end -->\n\nFooter\n",
+			generated: "new\n",
+			want:      "# Readme\n\n<!-- workflowdocgen:start -->\nnew\n<!-- workflowdocgen:end -->\n\nFooter\n",
+		},
+		{
+			name:      "empty section",
+			content:   "<!-- workflowdocgen:start --><!-- workflowdocgen:end -->",
+			generated: "table",
+			want:      "<!-- workflowdocgen:start -->\ntable\n<!-- workflowdocgen:end -->",
+		},
+		{
+			name:      "keeps indentation before end marker",
+			content:   "<ul>\n  <!-- workflowdocgen:start -->\n  <!-- workflowdocgen:end -->\n</ul>\n",
+			generated: "",
+			want:      "<ul>\n  <!-- workflowdocgen:start -->\n  <!-- workflowdocgen:end -->\n</ul>\n",
+		},
+		{
+			name:    "missing start marker",
+			content: "text\n<!-- workflowdocgen:end -->\n",
+			wantErr: "marker <!-- workflowdocgen:start --> not found",
+		},
+		{
+			name:    "missing end marker",
+			content: "<!-- workflowdocgen:start -->\ntext\n",
+			wantErr: "marker <!-- workflowdocgen:end --> not found",
+		},
+		{
+			name:    "duplicate end marker",
+			content: "<!-- workflowdocgen:start -->\n<!-- workflowdocgen:end -->\n\n<!-- workflowdocgen:end -->\n",
+			wantErr: "marker <!-- workflowdocgen:end --> found more than once, on lines 2 and 4",
+		},
+		{
+			name:    "end before start",
+			content: "<!-- workflowdocgen:end -->\n<!-- workflowdocgen:start -->\n",
+			wantErr: "comes before start marker",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InjectBetweenMarkers(tt.content, tt.generated)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing '%s', got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("InjectBetweenMarkers failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package workflowdocgen
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Param represents an input declared under on.workflow_dispatch.inputs or
+// on.workflow_call.inputs. Inputs declared by both events are merged.
+type Param struct {
+	Name        string
+	Type        string
+	Description string
+	Required    bool
+	Default     string
+	Options     []string
+	Events      []string
+}
+
+// parseInputs reads the inputs of the workflow_dispatch and workflow_call events
+func parseInputs(on *yaml.Node) []Param {
+	var params []Param
+	index := make(map[string]int)
+
+	for _, event := range []string{"workflow_dispatch", "workflow_call"} {
+		inputs := mappingValue(mappingValue(on, event), "inputs")
+		if inputs == nil || inputs.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for i := 0; i+1 < len(inputs.Content); i += 2 {
+			name, config := inputs.Content[i].Value, inputs.Content[i+1]
+
+			if existing, ok := index[name]; ok {
+				params[existing].Events = append(params[existing].Events, event)
+				continue
+			}
+
+			index[name] = len(params)
+			params = append(params, Param{
+				Name:        name,
+				Type:        scalarValue(mappingValue(config, "type")),
+				Description: scalarValue(mappingValue(config, "description")),
+				Required:    scalarValue(mappingValue(config, "required")) == "true",
+				Default:     scalarValue(mappingValue(config, "default")),
+				Options:     scalarList(mappingValue(config, "options")),
+				Events:      []string{event},
+			})
+		}
+	}
+
+	return params
+}
+
+// mergeDescription appends annotation text to a declared description
+func mergeDescription(declared, note string) string {
+	declared = strings.TrimSpace(declared)
+	note = strings.TrimSpace(note)
+	switch {
+	case note == "" || note == declared:
+		return declared
+	case declared == "":
+		return note
+	case strings.HasSuffix(declared, ".") || strings.HasSuffix(declared, "!") || strings.HasSuffix(declared, "?"):
+		return declared + " " + note
+	}
+	return declared + ". " + note
+}
@@ -0,0 +1,102 @@
+package workflowdocgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseInputs(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `name: Deploy
+on:
+  workflow_dispatch:
+    inputs:
+      environment:
+        # @param.environment: Production requires approval
+        description: Target environment
+        type: choice
+        required: true
+        default: staging
+        options: [staging, production]
+      dry-run:
+        description: Only print the plan.
+        type: boolean
+        default: false
+  workflow_call:
+    inputs:
+      environment:
+        type: string
+        required: true
+      version:
+        type: string
+# @param.version: Release tag to deploy
+# @param.unknown: Not declared anywhere
+`
+	filePath := filepath.Join(tempDir, "inputs.yml")
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	doc, err := ParseWorkflowFile(filePath)
+	if err != nil {
+		t.Fatalf("ParseWorkflowFile failed: %v", err)
+	}
+
+	if len(doc.Inputs) != 3 {
+		t.Fatalf("Expected 3 inputs, got %d: %+v", len(doc.Inputs), doc.Inputs)
+	}
+
+	environment := doc.Inputs[0]
+	if environment.Name != "environment" || environment.Type != "choice" || !environment.Required {
+		t.Errorf("Unexpected 'environment' input: %+v", environment)
+	}
+	if environment.Default != "staging" {
+		t.Errorf("Expected default 'staging', got '%s'", environment.Default)
+	}
+	if len(environment.Options) != 2 || environment.Options[1] != "production" {
+		t.Errorf("Expected options [staging production], got %v", environment.Options)
+	}
+	if environment.Description != "Target environment. Production requires approval" {
+		t.Errorf("Expected merged description, got '%s'", environment.Description)
+	}
+	if len(environment.Events) != 2 || environment.Events[1] != "workflow_call" {
+		t.Errorf("Expected events [workflow_dispatch workflow_call], got %v", environment.Events)
+	}
+
+	dryRun := doc.Inputs[1]
+	if dryRun.Type != "boolean" || dryRun.Required || dryRun.Default != "false" {
+		t.Errorf("Unexpected 'dry-run' input: %+v", dryRun)
+	}
+	if dryRun.Description != "Only print the plan." {
+		t.Errorf("Expected declared description only, got '%s'", dryRun.Description)
+	}
+
+	version := doc.Inputs[2]
+	if version.Description != "Release tag to deploy" {
+		t.Errorf("Expected annotation as description, got '%s'", version.Description)
+	}
+	if len(version.Events) != 1 || version.Events[0] != "workflow_call" {
+		t.Errorf("Expected events [workflow_call], got %v", version.Events)
+	}
+}
+
+func TestMergeDescription(t *testing.T) {
+	tests := []struct {
+		declared, note, want string
+	}{
+		{"", "", ""},
+		{"Declared", "", "Declared"},
+		{"", "Note", "Note"},
+		{"Declared", "Note", "Declared. Note"},
+		{"Declared.", "Note", "Declared. Note"},
+		{"Same", "Same", "Same"},
+	}
+
+	for _, tt := range tests {
+		if got := mergeDescription(tt.declared, tt.note); got != tt.want {
+			t.Errorf("mergeDescription(%q, %q) = %q, want %q", tt.declared, tt.note, got, tt.want)
+		}
+	}
+}
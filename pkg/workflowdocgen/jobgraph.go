@@ -0,0 +1,57 @@
+package workflowdocgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JobGraph returns the jobs of the workflow and their needs: edges as a
+// Mermaid flowchart, or "" if the workflow has fewer than two jobs. Matrix
+// jobs are drawn as hexagons and jobs with an if: condition with a dashed
+// border.
+func (d *WorkflowDoc) JobGraph() string {
+	if len(d.Jobs) < 2 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+
+	// Job IDs may clash with Mermaid keywords such as end, so nodes are
+	// numbered instead
+	ids := make(map[string]string, len(d.Jobs))
+	conditional := false
+	for i, job := range d.Jobs {
+		id := fmt.Sprintf("j%d", i)
+		ids[job.ID] = id
+
+		label := mermaidLabel(job.ID)
+		if job.Name != "" && job.Name != job.ID {
+			label = mermaidLabel(job.Name)
+		}
+		shape := `["%s"]`
+		if job.Matrix {
+			shape = `{{"%s"}}`
+		}
+		sb.WriteString("    " + id + fmt.Sprintf(shape, label))
+		if job.If != "" {
+			sb.WriteString(":::conditional")
+			conditional = true
+		}
+		sb.WriteString("\n")
+	}
+
+	for _, job := range d.Jobs {
+		for _, need := range job.Needs {
+			// GitHub rejects needs of unknown jobs, so they are left out
+			if from, ok := ids[need]; ok {
+				sb.WriteString(fmt.Sprintf("    %s --> %s\n", from, ids[job.ID]))
+			}
+		}
+	}
+
+	if conditional {
+		sb.WriteString("    classDef conditional stroke-dasharray:4 4\n")
+	}
+	return sb.String()
+}
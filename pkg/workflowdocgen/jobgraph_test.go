@@ -0,0 +1,77 @@
+package workflowdocgen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJobGraph(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := writeTestFile(t, tempDir, "ci.yml", `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+  test:
+    name: Unit "tests"
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os: [ubuntu-latest, windows-latest]
+    steps:
+      - run: make test
+  end:
+    needs: [lint, test, missing]
+    if: github.ref == 'refs/heads/main'
+    runs-on: ubuntu-latest
+    steps:
+      - run: make release
+`)
+
+	doc, err := ParseWorkflowFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse workflow: %v", err)
+	}
+
+	t.Run("matrix is parsed", func(t *testing.T) {
+		if doc.Jobs[0].Matrix || !doc.Jobs[1].Matrix || doc.Jobs[2].Matrix {
+			t.Errorf("Expected only the test job to be a matrix job")
+		}
+	})
+
+	t.Run("flowchart", func(t *testing.T) {
+		want := `flowchart LR
+    j0["lint"]
+    j1{{"Unit #quot;tests#quot;"}}
+    j2["end"]:::conditional
+    j0 --> j2
+    j1 --> j2
+    classDef conditional stroke-dasharray:4 4
+`
+		if got := doc.JobGraph(); got != want {
+			t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+		}
+	})
+
+	t.Run("single job has no graph", func(t *testing.T) {
+		single := &WorkflowDoc{Jobs: doc.Jobs[:1]}
+		if got := single.JobGraph(); got != "" {
+			t.Errorf("Expected no graph, got '%s'", got)
+		}
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		content, err := RenderMarkdownTable([]*WorkflowDoc{doc}, RenderOptions{})
+		if err != nil {
+			t.Fatalf("Failed to render markdown: %v", err)
+		}
+		want := "**Job dependencies:**\n\n```mermaid\nflowchart LR\n"
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected markdown to contain '%s', got:\n%s", want, content)
+		}
+		if strings.Index(content, want) > strings.Index(content, "#### Job: `lint`") {
+			t.Error("Expected the job graph before the jobs")
+		}
+	})
+}
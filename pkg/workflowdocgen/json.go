@@ -0,0 +1,285 @@
+package workflowdocgen
+
+import (
+	_ "embed"
+	"encoding/json"
+	"io"
+	"path/filepath"
+)
+
+// JSONSchemaVersion is the version of the JSON catalog format. It is bumped
+// whenever a field is removed or changes meaning; new optional fields do not
+// change the version, which is why the objects of JSONSchema allow
+// properties they do not list.
+const JSONSchemaVersion = 1
+
+// JSONSchema is the JSON Schema (draft 2020-12) of the JSON catalog format
+//
+//go:embed schema/workflow-catalog.v1.schema.json
+var JSONSchema []byte
+
+// JSONRenderer renders the workflow catalog as JSON
+type JSONRenderer struct {
+	// Actions are the local actions included in the catalog
+	Actions []*ActionDoc
+}
+
+// Render writes the catalog of docs to w as indented JSON
+func (r JSONRenderer) Render(w io.Writer, docs []*WorkflowDoc) error {
+	catalog := jsonCatalog{
+		SchemaVersion: JSONSchemaVersion,
+		Workflows:     make([]jsonWorkflow, 0, len(docs)),
+	}
+	for _, doc := range docs {
+		catalog.Workflows = append(catalog.Workflows, newJSONWorkflow(doc))
+	}
+	for _, action := range r.Actions {
+		catalog.Actions = append(catalog.Actions, newJSONAction(action))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(catalog)
+}
+
+// The json* types define the serialised format. They are kept separate from
+// WorkflowDoc so that the format only changes deliberately.
+
+type jsonCatalog struct {
+	SchemaVersion int            `json:"schemaVersion"`
+	Workflows     []jsonWorkflow `json:"workflows"`
+	Actions       []jsonAction   `json:"actions,omitempty"`
+}
+
+type jsonWorkflow struct {
+	File                string             `json:"file"`
+	Path                string             `json:"path"`
+	Name                string             `json:"name,omitempty"`
+	NameSource          NameSource         `json:"nameSource,omitempty"`
+	DeclaredName        string             `json:"declaredName,omitempty"`
+	Description         string             `json:"description,omitempty"`
+	Owners              []string           `json:"owners,omitempty"`
+	Tags                []string           `json:"tags,omitempty"`
+	Params              string             `json:"params,omitempty"`
+	Results             string             `json:"results,omitempty"`
+	Permissions         string             `json:"permissions,omitempty"`
+	Requirements        string             `json:"requirements,omitempty"`
+	TriggersNote        string             `json:"triggersNote,omitempty"`
+	Reusable            bool               `json:"reusable"`
+	Triggers            []jsonTrigger      `json:"triggers,omitempty"`
+	Inputs              []jsonInput        `json:"inputs,omitempty"`
+	Outputs             []jsonOutput       `json:"outputs,omitempty"`
+	Secrets             []jsonSecret       `json:"secrets,omitempty"`
+	DeclaredPermissions *jsonPermissionSet `json:"declaredPermissions,omitempty"`
+	Jobs                []jsonJob          `json:"jobs,omitempty"`
+	Extra               map[string]string  `json:"extra,omitempty"`
+}
+
+type jsonTrigger struct {
+	Event          string   `json:"event"`
+	Description    string   `json:"description,omitempty"`
+	Types          []string `json:"types,omitempty"`
+	Branches       []string `json:"branches,omitempty"`
+	BranchesIgnore []string `json:"branchesIgnore,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	TagsIgnore     []string `json:"tagsIgnore,omitempty"`
+	Paths          []string `json:"paths,omitempty"`
+	PathsIgnore    []string `json:"pathsIgnore,omitempty"`
+	Workflows      []string `json:"workflows,omitempty"`
+	Cron           string   `json:"cron,omitempty"`
+}
+
+type jsonInput struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Required    bool     `json:"required"`
+	Default     string   `json:"default,omitempty"`
+	Options     []string `json:"options,omitempty"`
+	Events      []string `json:"events"`
+}
+
+type jsonOutput struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Value       string `json:"value,omitempty"`
+}
+
+type jsonSecret struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+}
+
+type jsonPermissionSet struct {
+	Source string            `json:"source"`
+	Preset string            `json:"preset,omitempty"`
+	Scopes map[string]string `json:"scopes,omitempty"`
+}
+
+type jsonJob struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Owners               []string           `json:"owners,omitempty"`
+	Permissions          string             `json:"permissions,omitempty"`
+	Requirements         string             `json:"requirements,omitempty"`
+	RunsOn               string             `json:"runsOn,omitempty"`
+	Needs                []string           `json:"needs,omitempty"`
+	If                   string             `json:"if,omitempty"`
+	Uses                 string             `json:"uses,omitempty"`
+	Matrix               bool               `json:"matrix,omitempty"`
+	DeclaredPermissions  *jsonPermissionSet `json:"declaredPermissions,omitempty"`
+	EffectivePermissions *jsonPermissionSet `json:"effectivePermissions"`
+	Steps                []jsonStep         `json:"steps,omitempty"`
+}
+
+type jsonStep struct {
+	Index       int    `json:"index"`
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Uses        string `json:"uses,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type jsonAction struct {
+	Path         string            `json:"path"`
+	Reference    string            `json:"reference"`
+	Name         string            `json:"name,omitempty"`
+	DeclaredName string            `json:"declaredName,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	Author       string            `json:"author,omitempty"`
+	Owners       []string          `json:"owners,omitempty"`
+	Tags         []string          `json:"tags,omitempty"`
+	Requirements string            `json:"requirements,omitempty"`
+	Using        string            `json:"using,omitempty"`
+	Main         string            `json:"main,omitempty"`
+	Image        string            `json:"image,omitempty"`
+	Inputs       []jsonActionInput `json:"inputs,omitempty"`
+	Outputs      []jsonOutput      `json:"outputs,omitempty"`
+	Branding     *jsonBranding     `json:"branding,omitempty"`
+	Extra        map[string]string `json:"extra,omitempty"`
+}
+
+type jsonActionInput struct {
+	Name               string `json:"name"`
+	Description        string `json:"description,omitempty"`
+	Required           bool   `json:"required"`
+	Default            string `json:"default,omitempty"`
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+}
+
+type jsonBranding struct {
+	Icon  string `json:"icon,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+// newJSONWorkflow converts a WorkflowDoc into its serialised form
+func newJSONWorkflow(doc *WorkflowDoc) jsonWorkflow {
+	workflow := jsonWorkflow{
+		File:                doc.FileName,
+		Path:                filepath.ToSlash(doc.FilePath),
+		Name:                doc.DisplayName(),
+		NameSource:          doc.NameSource,
+		DeclaredName:        doc.DeclaredName,
+		Description:         doc.Description,
+		Owners:              doc.OwnerHandles(),
+		Tags:                doc.TagNames(),
+		Params:              doc.Params,
+		Results:             doc.Results,
+		Permissions:         doc.Permissions,
+		Requirements:        doc.Requirements,
+		TriggersNote:        doc.TriggersNote,
+		Reusable:            doc.IsReusable(),
+		DeclaredPermissions: newJSONPermissionSet(doc.DeclaredPermissions),
+		Extra:               doc.Extra,
+	}
+
+	for _, trigger := range doc.Triggers {
+		workflow.Triggers = append(workflow.Triggers, jsonTrigger{
+			Event:          trigger.Event,
+			Description:    trigger.Describe(),
+			Types:          trigger.Types,
+			Branches:       trigger.Branches,
+			BranchesIgnore: trigger.BranchesIgnore,
+			Tags:           trigger.Tags,
+			TagsIgnore:     trigger.TagsIgnore,
+			Paths:          trigger.Paths,
+			PathsIgnore:    trigger.PathsIgnore,
+			Workflows:      trigger.Workflows,
+			Cron:           trigger.Cron,
+		})
+	}
+
+	for _, input := range doc.Inputs {
+		workflow.Inputs = append(workflow.Inputs, jsonInput(input))
+	}
+	for _, output := range doc.Outputs {
+		workflow.Outputs = append(workflow.Outputs, jsonOutput(output))
+	}
+	for _, secret := range doc.Secrets {
+		workflow.Secrets = append(workflow.Secrets, jsonSecret(secret))
+	}
+
+	for _, job := range doc.Jobs {
+		j := jsonJob{
+			ID:                   job.ID,
+			Name:                 job.Name,
+			Description:          job.Description,
+			Owners:               job.OwnerHandles(),
+			Permissions:          job.Permissions,
+			Requirements:         job.Requirements,
+			RunsOn:               job.RunsOn,
+			Needs:                job.Needs,
+			If:                   job.If,
+			Uses:                 job.Uses,
+			Matrix:               job.Matrix,
+			DeclaredPermissions:  newJSONPermissionSet(job.DeclaredPermissions),
+			EffectivePermissions: newJSONPermissionSet(doc.EffectivePermissions(job)),
+		}
+		for _, step := range job.Steps {
+			j.Steps = append(j.Steps, jsonStep(*step))
+		}
+		workflow.Jobs = append(workflow.Jobs, j)
+	}
+
+	return workflow
+}
+
+// newJSONPermissionSet converts a permissions block, or returns nil if there is none
+func newJSONPermissionSet(p *PermissionSet) *jsonPermissionSet {
+	if p == nil {
+		return nil
+	}
+	return &jsonPermissionSet{Source: p.Source, Preset: p.Preset, Scopes: p.Scopes}
+}
+
+// newJSONAction converts an ActionDoc into its serialised form
+func newJSONAction(doc *ActionDoc) jsonAction {
+	action := jsonAction{
+		Path:         filepath.ToSlash(doc.FilePath),
+		Reference:    doc.Reference(),
+		Name:         doc.DisplayName(),
+		DeclaredName: doc.DeclaredName,
+		Description:  doc.Description,
+		Author:       doc.Author,
+		Owners:       doc.OwnerHandles(),
+		Tags:         doc.TagNames(),
+		Requirements: doc.Requirements,
+		Using:        doc.Using,
+		Main:         doc.Main,
+		Image:        doc.Image,
+		Extra:        doc.Extra,
+	}
+	for _, input := range doc.Inputs {
+		action.Inputs = append(action.Inputs, jsonActionInput(input))
+	}
+	for _, output := range doc.Outputs {
+		action.Outputs = append(action.Outputs, jsonOutput(output))
+	}
+	if doc.Branding != nil {
+		action.Branding = &jsonBranding{Icon: doc.Branding.Icon, Color: doc.Branding.Color}
+	}
+	return action
+}
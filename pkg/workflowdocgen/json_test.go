@@ -0,0 +1,278 @@
+package workflowdocgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestJSONRenderer(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `# @workflow.description: Build and test
+# @workflow.owners: @Org/Platform
+# @workflow.tags: CI, Go
+# @workflow.runbook: docs/ci.md
+name: CI
+on:
+  push:
+    branches: [main]
+  schedule:
+    - cron: "0 3 * * *"
+  workflow_call:
+    inputs:
+      version:
+        type: string
+        required: true
+    outputs:
+      artifact:
+        description: Artifact name
+        value: ${{ jobs.build.outputs.artifact }}
+    secrets:
+      token:
+        required: false
+permissions:
+  contents: read
+jobs:
+  build:
+    # @job.description: Build binaries
+    runs-on: ubuntu-latest
+    permissions: write-all
+    steps:
+      - uses: actions/checkout@v6
+      - name: Build
+        run: make build
+  test:
+    needs: build
+    if: github.event_name == 'push'
+    runs-on: ubuntu-latest
+    strategy:
+      matrix:
+        go: ["1.24", "1.25"]
+    steps:
+      - run: make test
+`
+	filePath := filepath.Join(tempDir, "ci.yml")
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	doc, err := ParseWorkflowFileWithOptions(filePath, ParseOptions{CustomKeys: []string{"runbook"}})
+	if err != nil {
+		t.Fatalf("ParseWorkflowFile failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := (JSONRenderer{}).Render(&buf, []*WorkflowDoc{doc, {FileName: "bare.yml", FilePath: "bare.yml"}}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	var catalog map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &catalog); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	t.Run("matches schema", func(t *testing.T) {
+		var schema map[string]any
+		if err := json.Unmarshal(JSONSchema, &schema); err != nil {
+			t.Fatalf("Schema is not valid JSON: %v", err)
+		}
+		if err := validateSchema(schema, schema, catalog, "$"); err != nil {
+			t.Errorf("Output does not match schema: %v\n%s", err, buf.String())
+		}
+	})
+
+	t.Run("content", func(t *testing.T) {
+		if catalog["schemaVersion"] != float64(JSONSchemaVersion) {
+			t.Errorf("Expected schemaVersion %d, got %v", JSONSchemaVersion, catalog["schemaVersion"])
+		}
+
+		output := buf.String()
+		expected := []string{
+			`"name": "CI"`,
+			`"nameSource": "declared"`,
+			`"owners": [
+        "org/platform"
+      ]`,
+			`"reusable": true`,
+			`"description": "At 03:00 UTC every day (` + "`0 3 * * *`" + `)"`,
+			`"value": "${{ jobs.build.outputs.artifact }}"`,
+			`"runbook": "docs/ci.md"`,
+			`"effectivePermissions": {
+            "source": "job",
+            "preset": "write-all"
+          }`,
+			`"effectivePermissions": {
+            "source": "workflow",
+            "scopes": {
+              "contents": "read"
+            }
+          }`,
+			`"uses": "actions/checkout@v6"`,
+			`"if": "github.event_name == 'push'"`,
+			`"matrix": true`,
+		}
+		for _, want := range expected {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %s in output, got:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("actions", func(t *testing.T) {
+		action, err := ParseActionFile(writeTestFile(t, tempDir, "setup-go/action.yml", setupGoAction))
+		if err != nil {
+			t.Fatalf("ParseActionFile failed: %v", err)
+		}
+
+		var withActions bytes.Buffer
+		if err := (JSONRenderer{Actions: []*ActionDoc{action, {FilePath: "bare/action.yml", Dir: "bare"}}}).Render(&withActions, nil); err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+
+		var catalog map[string]any
+		if err := json.Unmarshal(withActions.Bytes(), &catalog); err != nil {
+			t.Fatalf("Output is not valid JSON: %v", err)
+		}
+		var schema map[string]any
+		if err := json.Unmarshal(JSONSchema, &schema); err != nil {
+			t.Fatalf("Schema is not valid JSON: %v", err)
+		}
+		if err := validateSchema(schema, schema, catalog, "$"); err != nil {
+			t.Errorf("Output does not match schema: %v\n%s", err, withActions.String())
+		}
+
+		for _, want := range []string{`"reference": "./bare"`, `"using": "composite"`, `"deprecationMessage": "Use cache instead"`, `"icon": "package"`} {
+			if !strings.Contains(withActions.String(), want) {
+				t.Errorf("Expected %s in output, got:\n%s", want, withActions.String())
+			}
+		}
+	})
+
+	t.Run("empty catalog", func(t *testing.T) {
+		var empty bytes.Buffer
+		if err := (JSONRenderer{}).Render(&empty, nil); err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		if got := strings.Join(strings.Fields(empty.String()), ""); got != `{"schemaVersion":1,"workflows":[]}` {
+			t.Errorf("Expected empty workflows array, got '%s'", got)
+		}
+	})
+}
+
+// validateSchema checks value against the subset of JSON Schema used by the
+// shipped catalog schema: $ref, type, const, enum, minimum, required,
+// properties, additionalProperties and items. Unlike a JSON Schema validator,
+// it rejects properties that an object schema does not list, so that every
+// field of the output is documented in the schema.
+func validateSchema(root, schema map[string]any, value any, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/$defs/")
+		def, ok := root["$defs"].(map[string]any)[name].(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: unknown $ref %s", path, ref)
+		}
+		return validateSchema(root, def, value, path)
+	}
+
+	if want, ok := schema["const"]; ok && value != want {
+		return fmt.Errorf("%s: expected %v, got %v", path, want, value)
+	}
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, allowed := range enum {
+			found = found || allowed == value
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, value, enum)
+		}
+	}
+
+	switch schema["type"] {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: expected string, got %T", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", path, value)
+		}
+	case "integer":
+		n, ok := value.(float64)
+		if !ok || n != float64(int(n)) {
+			return fmt.Errorf("%s: expected integer, got %v", path, value)
+		}
+		if minimum, ok := schema["minimum"].(float64); ok && n < minimum {
+			return fmt.Errorf("%s: %v is below minimum %v", path, n, minimum)
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", path, value)
+		}
+		itemSchema, _ := schema["items"].(map[string]any)
+		for i, item := range items {
+			if err := validateSchema(root, itemSchema, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", path, value)
+		}
+		required, _ := schema["required"].([]any)
+		for _, key := range required {
+			if _, ok := object[key.(string)]; !ok {
+				return fmt.Errorf("%s: missing required property %s", path, key)
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		for key, property := range object {
+			propertySchema, ok := properties[key].(map[string]any)
+			if !ok {
+				additional, isSchema := schema["additionalProperties"].(map[string]any)
+				if !isSchema {
+					return fmt.Errorf("%s: unexpected property %s", path, key)
+				}
+				propertySchema = additional
+			}
+			if err := validateSchema(root, propertySchema, property, path+"."+key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestJSONSchemaIsOpen(t *testing.T) {
+	var schema any
+	if err := json.Unmarshal(JSONSchema, &schema); err != nil {
+		t.Fatalf("Schema is not valid JSON: %v", err)
+	}
+
+	// Closed objects would reject the optional fields later releases add
+	// without bumping JSONSchemaVersion
+	var walk func(node any, path string)
+	walk = func(node any, path string) {
+		switch node := node.(type) {
+		case map[string]any:
+			if node["additionalProperties"] == false {
+				t.Errorf("%s: expected additionalProperties not to be false", path)
+			}
+			for key, child := range node {
+				walk(child, path+"."+key)
+			}
+		case []any:
+			for i, child := range node {
+				walk(child, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	}
+	walk(schema, "$")
+}
@@ -0,0 +1,93 @@
+package workflowdocgen
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Names of the checks of Lint, used as Diagnostic.Rule
+const (
+	RuleRequiredFields       = "required-fields"
+	RuleAllowedTags          = "allowed-tags"
+	RuleOwnerFormat          = "owner-format"
+	RuleMaxDescriptionLength = "max-description-length"
+	RuleNameMismatch         = "name-mismatch"
+)
+
+// LintRules configures the checks of Lint
+type LintRules struct {
+	// RequiredFields lists the fields every workflow must have, see MissingFields
+	RequiredFields []string
+	// AllowedTags is the tag vocabulary; any tag is allowed when it is empty
+	AllowedTags []string
+	// OwnerPattern, if set, must match every owner handle. Owners must be
+	// valid GitHub user or org/team handles in any case.
+	OwnerPattern *regexp.Regexp
+	// MaxDescriptionLength is the maximum number of characters of the
+	// description; 0 means no limit
+	MaxDescriptionLength int
+	// NameMismatch reports an @workflow.name that differs from name:, see
+	// WorkflowDoc.NameMismatch
+	NameMismatch bool
+}
+
+// Lint checks a workflow against rules and returns the problems found,
+// including the diagnostics of the parser, ordered by position. Problems
+// without an annotation to point at, such as a missing field, are reported at
+// the start of the file.
+func Lint(doc *WorkflowDoc, rules LintRules) []Diagnostic {
+	diagnostics := slices.Clone(doc.Diagnostics)
+	report := func(field, rule, format string, args ...any) {
+		pos := doc.Positions[field]
+		if pos.Line == 0 {
+			pos = Position{Line: 1, Column: 1}
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			File:     doc.FilePath,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Severity: SeverityError,
+			Rule:     rule,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	for _, field := range doc.MissingFields(rules.RequiredFields) {
+		report(field, RuleRequiredFields, "missing required annotation @workflow.%s", field)
+	}
+
+	if len(rules.AllowedTags) > 0 {
+		allowed := make([]string, len(rules.AllowedTags))
+		for i, tag := range rules.AllowedTags {
+			allowed[i] = strings.ToLower(tag)
+		}
+		for _, tag := range doc.TagNames() {
+			if !slices.Contains(allowed, tag) {
+				report("tags", RuleAllowedTags, "tag %q is not allowed, expected one of: %s", tag, strings.Join(allowed, ", "))
+			}
+		}
+	}
+
+	for _, owner := range doc.OwnerHandles() {
+		switch {
+		case !ownerPattern.MatchString(owner):
+			report("owners", RuleOwnerFormat, "owner %q is not a GitHub user or org/team handle", owner)
+		case rules.OwnerPattern != nil && !rules.OwnerPattern.MatchString(owner):
+			report("owners", RuleOwnerFormat, "owner %q does not match the pattern %s", owner, rules.OwnerPattern)
+		}
+	}
+
+	if length := utf8.RuneCountInString(doc.Description); rules.MaxDescriptionLength > 0 && length > rules.MaxDescriptionLength {
+		report("description", RuleMaxDescriptionLength, "description is %d characters long, more than the maximum of %d", length, rules.MaxDescriptionLength)
+	}
+
+	if rules.NameMismatch && doc.NameMismatch() {
+		report("name", RuleNameMismatch, "annotated name %q differs from the declared name %q", doc.Name, doc.DeclaredName)
+	}
+
+	sortDiagnostics(diagnostics)
+	return diagnostics
+}
@@ -0,0 +1,123 @@
+package workflowdocgen
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "deploy.yml")
+	content := `# @workflow.name: Deploy
+# @workflow.description: Deploys the service to every environment
+# @workflow.owners: acme/platform, @octocat, Not A Handle
+# @workflow.tags: deploy, experimental
+name: Deploy
+on: push
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    steps:
+      - run: ./deploy.sh
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil { // #nosec G306 - test file
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	doc, err := ParseWorkflowFile(path)
+	if err != nil {
+		t.Fatalf("Failed to parse workflow: %v", err)
+	}
+
+	t.Run("no rules", func(t *testing.T) {
+		diagnostics := Lint(doc, LintRules{})
+		if len(diagnostics) != 1 || diagnostics[0].Rule != RuleOwnerFormat {
+			t.Fatalf("Expected only the invalid owner handle, got %v", diagnostics)
+		}
+		expected := path + `:3:1: error: owner "not a handle" is not a GitHub user or org/team handle`
+		if diagnostics[0].String() != expected {
+			t.Errorf("Expected '%s', got '%s'", expected, diagnostics[0])
+		}
+	})
+
+	t.Run("all rules", func(t *testing.T) {
+		diagnostics := Lint(doc, LintRules{
+			RequiredFields:       []string{"description", "owners", "runbook"},
+			AllowedTags:          []string{"Deploy", "ci"},
+			OwnerPattern:         regexp.MustCompile(`^acme/`),
+			MaxDescriptionLength: 20,
+		})
+
+		var got []string
+		for _, d := range diagnostics {
+			got = append(got, d.String())
+		}
+		expected := []string{
+			path + ":1:1: error: missing required annotation @workflow.runbook",
+			path + ":2:1: error: description is 40 characters long, more than the maximum of 20",
+			path + `:3:1: error: owner "octocat" does not match the pattern ^acme/`,
+			path + `:3:1: error: owner "not a handle" is not a GitHub user or org/team handle`,
+			path + `:4:1: error: tag "experimental" is not allowed, expected one of: deploy, ci`,
+		}
+		if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+			t.Errorf("Expected diagnostics:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+		}
+	})
+
+	t.Run("passing workflow", func(t *testing.T) {
+		passing := &WorkflowDoc{FilePath: "ci.yml", Description: "Build", Owners: "acme/ci", Tags: "ci"}
+		rules := LintRules{
+			RequiredFields:       []string{"description", "owners"},
+			AllowedTags:          []string{"ci"},
+			OwnerPattern:         regexp.MustCompile(`^acme/`),
+			MaxDescriptionLength: 5,
+		}
+		if diagnostics := Lint(passing, rules); len(diagnostics) != 0 {
+			t.Errorf("Expected no diagnostics, got %v", diagnostics)
+		}
+	})
+
+	t.Run("name mismatch", func(t *testing.T) {
+		mismatch := &WorkflowDoc{
+			FilePath:     "ci.yml",
+			Name:         "Build",
+			DeclaredName: "CI",
+			Positions:    map[string]Position{"name": {Line: 2, Column: 1}},
+		}
+		if diagnostics := Lint(mismatch, LintRules{}); len(diagnostics) != 0 {
+			t.Errorf("Expected the rule to be opt-in, got %v", diagnostics)
+		}
+		diagnostics := Lint(mismatch, LintRules{NameMismatch: true})
+		expected := `ci.yml:2:1: error: annotated name "Build" differs from the declared name "CI"`
+		if len(diagnostics) != 1 || diagnostics[0].String() != expected || diagnostics[0].Rule != RuleNameMismatch {
+			t.Errorf("Expected '%s', got %v", expected, diagnostics)
+		}
+		if diagnostics := Lint(doc, LintRules{NameMismatch: true}); len(diagnostics) != 1 {
+			t.Errorf("Expected no mismatch for equal names, got %v", diagnostics)
+		}
+	})
+
+	t.Run("includes parser diagnostics", func(t *testing.T) {
+		withDiagnostics := &WorkflowDoc{
+			FilePath: "ci.yml",
+			Diagnostics: []Diagnostic{
+				{File: "ci.yml", Line: 3, Column: 1, Severity: SeverityWarning, Rule: RuleMalformedAnnotation, Message: "missing colon after @workflow.owners"},
+			},
+		}
+		diagnostics := Lint(withDiagnostics, LintRules{RequiredFields: []string{"owners"}})
+		expected := []string{
+			"ci.yml:1:1: error: missing required annotation @workflow.owners",
+			"ci.yml:3:1: warning: missing colon after @workflow.owners",
+		}
+		if len(diagnostics) != len(expected) {
+			t.Fatalf("Expected %d diagnostics, got %v", len(expected), diagnostics)
+		}
+		for i, want := range expected {
+			if diagnostics[i].String() != want {
+				t.Errorf("Expected '%s', got '%s'", want, diagnostics[i])
+			}
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package workflowdocgen
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ownerPattern matches a GitHub user, organisation or org/team handle
+	ownerPattern = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]*[a-z0-9])?(?:/[a-z0-9][a-z0-9_.-]*)?$`)
+	// tagPattern matches tags that can be rendered as a badge
+	tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9 ._+-]*$`)
+)
+
+// parseList splits a comma- or newline-separated annotation value into
+// trimmed, lowercased, distinct items. Runs of whitespace are collapsed.
+func parseList(value string) []string {
+	return splitItems(value, "")
+}
+
+// parseOwners parses an owners annotation; a leading @ of a handle is dropped
+func parseOwners(value string) []string {
+	return splitItems(value, "@")
+}
+
+// splitItems implements parseList, removing prefix from every item
+func splitItems(value, prefix string) []string {
+	var items []string
+	seen := make(map[string]bool)
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' }) {
+		item = strings.TrimPrefix(strings.ToLower(strings.Join(strings.Fields(item), " ")), prefix)
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		items = append(items, item)
+	}
+	return items
+}
+
+// listOrParse returns list, or parses raw when only the annotation text is set
+func listOrParse(list []string, raw string, parse func(string) []string) []string {
+	if list == nil {
+		return parse(raw)
+	}
+	return list
+}
+
+// OwnerHandles returns the normalised owners of the workflow
+func (d *WorkflowDoc) OwnerHandles() []string {
+	return listOrParse(d.OwnerList, d.Owners, parseOwners)
+}
+
+// TagNames returns the normalised tags of the workflow
+func (d *WorkflowDoc) TagNames() []string {
+	return listOrParse(d.TagList, d.Tags, parseList)
+}
+
+// OwnerHandles returns the normalised owners of the job
+func (j *JobDoc) OwnerHandles() []string {
+	return listOrParse(j.OwnerList, j.Owners, parseOwners)
+}
+
+// ownerMention renders an owner as an @mention linking to its GitHub page.
+// Values that are not valid handles are returned as escaped text.
+func ownerMention(owner string) string {
+	url := ownerURL(owner)
+	if url == "" {
+		return escapeMarkdown(owner)
+	}
+	return fmt.Sprintf("[@%s](%s)", owner, url)
+}
+
+// ownerURL returns the GitHub page of an owner: the team page of an org/team
+// handle and the profile of any other handle. The URL is empty if the owner
+// is not a valid handle.
+func ownerURL(owner string) string {
+	if !ownerPattern.MatchString(owner) {
+		return ""
+	}
+	if org, team, ok := strings.Cut(owner, "/"); ok {
+		return fmt.Sprintf("https://github.com/orgs/%s/teams/%s", org, team)
+	}
+	return "https://github.com/" + owner
+}
+
+// ownerMentions renders a list of owners, or "-" when there are none
+func ownerMentions(owners []string) string {
+	if len(owners) == 0 {
+		return "-"
+	}
+	mentions := make([]string, len(owners))
+	for i, owner := range owners {
+		mentions[i] = ownerMention(owner)
+	}
+	return strings.Join(mentions, ", ")
+}
+
+// tagBadge renders a tag as a shields.io badge image, or as escaped text
+// when the tag contains characters that do not belong in a badge
+func tagBadge(tag string) string {
+	if !tagPattern.MatchString(tag) {
+		return escapeMarkdown(tag)
+	}
+	// shields.io uses - and _ as separators; literal ones are doubled
+	label := strings.NewReplacer("-", "--", "_", "__").Replace(tag)
+	return fmt.Sprintf("![%s](https://img.shields.io/badge/%s-blue)", tag, url.PathEscape(label))
+}
+
+// tagBadges renders a list of tags, or "-" when there are none
+func tagBadges(tags []string) string {
+	if len(tags) == 0 {
+		return "-"
+	}
+	badges := make([]string, len(tags))
+	for i, tag := range tags {
+		badges[i] = tagBadge(tag)
+	}
+	return strings.Join(badges, " ")
+}
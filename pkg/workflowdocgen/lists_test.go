@@ -0,0 +1,75 @@
+package workflowdocgen
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseList(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{"empty", "", nil},
+		{"comma with spaces", "ci, testing", []string{"ci", "testing"}},
+		{"comma without spaces", "ci,testing", []string{"ci", "testing"}},
+		{"case and whitespace", "  CI ,  Multi   Word ", []string{"ci", "multi word"}},
+		{"duplicates and empty items", "ci,,CI, ci", []string{"ci"}},
+		{"newlines", "ci\ntesting", []string{"ci", "testing"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseList(tt.value); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseOwners(t *testing.T) {
+	got := parseOwners("@Org/Team-A, team-b, @team-b")
+	want := []string{"org/team-a", "team-b"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestOwnerMention(t *testing.T) {
+	tests := []struct {
+		owner string
+		want  string
+	}{
+		{"org/team", "[@org/team](https://github.com/orgs/org/teams/team)"},
+		{"alice", "[@alice](https://github.com/alice)"},
+		{"team | ops", "team \\| ops"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.owner, func(t *testing.T) {
+			if got := ownerMention(tt.owner); got != tt.want {
+				t.Errorf("Expected '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTagBadge(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"ci", "![ci](https://img.shields.io/badge/ci-blue)"},
+		{"multi-platform", "![multi-platform](https://img.shields.io/badge/multi--platform-blue)"},
+		{"tag1 | tag2", "tag1 \\| tag2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := tagBadge(tt.tag); got != tt.want {
+				t.Errorf("Expected '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
@@ -1,31 +1,460 @@
 package workflowdocgen
 
 import (
-	"bufio"
 	"fmt"
+	"io"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 )
 
+// NameSource identifies where the display name of a workflow comes from
+type NameSource string
+
+const (
+	// NameSourceNone means neither an annotation nor the YAML declares a name
+	NameSourceNone NameSource = ""
+	// NameSourceAnnotation means the name comes from @workflow.name
+	NameSourceAnnotation NameSource = "annotation"
+	// NameSourceDeclared means the name comes from the top-level YAML name: key
+	NameSourceDeclared NameSource = "declared"
+)
+
 // WorkflowDoc represents the documentation for a workflow
 type WorkflowDoc struct {
+	// Name is the @workflow.name annotation; see DisplayName for the fallback
 	Name         string
+	DeclaredName string
+	NameSource   NameSource
 	Description  string
+	// Owners and Tags hold the annotation text as written; OwnerList and
+	// TagList hold the normalised, lowercased items
 	Owners       string
 	Tags         string
+	OwnerList    []string
+	TagList      []string
 	Params       string
 	Results      string
 	Permissions  string
 	Requirements string
+	TriggersNote string
 	FilePath     string
 	FileName     string
+	Triggers     []Trigger
+	Inputs       []Param
+	Outputs      []Output
+	Secrets      []Secret
+	// DeclaredPermissions is the workflow-level permissions: block, if any
+	DeclaredPermissions *PermissionSet
+	Jobs                []*JobDoc
+	// Extra holds @workflow.* annotations that are not built-in fields
+	Extra map[string]string
+	// Positions holds where each @workflow.* annotation starts, by key
+	Positions map[string]Position
+	// Diagnostics holds problems with the annotations found while parsing
+	Diagnostics []Diagnostic
+}
+
+// Position is a 1-based line and column in a workflow file
+type Position struct {
+	Line   int
+	Column int
+}
+
+// ParseOptions configures how workflow files are parsed
+type ParseOptions struct {
+	// CustomKeys lists @workflow.* keys that are expected in addition to the
+	// built-in ones. Other unknown keys are kept in Extra but logged as warnings.
+	CustomKeys []string
+	// Recursive makes ParseWorkflowsDirectoryWithOptions descend into
+	// subdirectories
+	Recursive bool
+	// Include and Exclude are glob patterns that select the files of a
+	// directory. A pattern without a slash matches the file name, e.g.
+	// "deploy-*.yml"; otherwise it matches the path relative to the
+	// directory, where ** matches any number of directories, e.g.
+	// "templates/**/*.yml".
+	Include []string
+	Exclude []string
+}
+
+// DisplayName returns the annotated name, falling back to the declared name
+func (d *WorkflowDoc) DisplayName() string {
+	if d.Name != "" {
+		return d.Name
+	}
+	return d.DeclaredName
+}
+
+// NameMismatch reports whether the annotated and declared names disagree
+func (d *WorkflowDoc) NameMismatch() bool {
+	return d.Name != "" && d.DeclaredName != "" && d.Name != d.DeclaredName
+}
+
+// MissingFields returns the fields that have no value. Fields are annotation
+// keys such as "description" or "owners"; "name" and "triggers" are also
+// satisfied by the YAML, and other keys are looked up in Extra.
+func (d *WorkflowDoc) MissingFields(fields []string) []string {
+	var missing []string
+	for _, field := range fields {
+		var value string
+		switch field {
+		case "name":
+			value = d.DisplayName()
+		case "description":
+			value = d.Description
+		case "owners":
+			value = d.Owners
+		case "tags":
+			value = d.Tags
+		case "params":
+			value = d.Params
+		case "results":
+			value = d.Results
+		case "permissions":
+			value = d.Permissions
+		case "requirements":
+			value = d.Requirements
+		case "triggers":
+			if len(d.Triggers) > 0 {
+				continue
+			}
+			value = d.TriggersNote
+		default:
+			value = d.Extra[field]
+		}
+		if strings.TrimSpace(value) == "" {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+// JobDoc represents the documentation for a single job of a workflow
+type JobDoc struct {
+	ID           string
+	Name         string
+	Description  string
+	Owners       string
+	OwnerList    []string
+	Permissions  string
+	Requirements string
+	RunsOn       string
+	Needs        []string
+	If           string
+	Uses         string
+	// Call is the reusable workflow named by Uses, if any
+	Call *WorkflowRef
+	// Matrix is set if the job runs once per combination of a strategy.matrix
+	Matrix bool
+	// DeclaredPermissions is the job-level permissions: block, if any
+	DeclaredPermissions *PermissionSet
+	Steps               []*StepDoc
+}
+
+// StepDoc represents the documentation for a single step of a job
+type StepDoc struct {
+	Index       int
+	ID          string
+	Name        string
+	Uses        string
+	Description string
+}
+
+// Annotation kinds of workflow files, and the built-in fields of workflow,
+// job and step annotations
+var (
+	workflowKinds  = []string{"workflow", "job", "step", "param", "output", "secret"}
+	workflowFields = []string{"name", "description", "owners", "tags", "params", "results", "permissions", "requirements", "triggers"}
+	jobFields      = []string{"name", "description", "owners", "permissions", "requirements"}
+	stepFields     = []string{"name", "description"}
+)
+
+// nearMissPattern matches comments that look like an annotation of any kind
+// and capitalisation, with or without the colon
+var nearMissPattern = regexp.MustCompile(`^#\s*@([A-Za-z]+)\.([A-Za-z0-9_-]*)(\s*:)?`)
+
+// annotation is a single annotation field found in a comment line
+type annotation struct {
+	kind   string
+	field  string
+	value  string
+	line   int
+	column int
+}
+
+// indentColumn returns the 1-based column of the first non-blank character
+func indentColumn(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t")) + 1
+}
+
+// annotationValue completes the value of an annotation with its continuation
+// lines and returns it together with the index of the last line consumed.
+//
+// Continuation lines are comment lines in the same column as the annotation
+// that are indented by at least two spaces after the "#"; "#" on its own
+// separates paragraphs. A value of "|" starts a block: every following comment
+// line in the same column belongs to the value, up to the next annotation.
+func annotationValue(first string, lines []string, start, column int) (string, int) {
+	first = strings.TrimSpace(first)
+	block := first == "|"
+
+	var extra []string
+	next := start
+scan:
+	for ; next < len(lines); next++ {
+		line := lines[next]
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "#") || indentColumn(line) != column {
+			break
+		}
+		text := strings.TrimRight(trimmed[1:], " \t")
+		switch {
+		case strings.HasPrefix(strings.TrimSpace(text), "@"):
+			break scan
+		case block:
+			extra = append(extra, strings.TrimPrefix(text, " "))
+		case text == "":
+			extra = append(extra, "")
+		case strings.HasPrefix(text, "  "):
+			extra = append(extra, text)
+		default:
+			break scan
+		}
+	}
+
+	// Blank comment lines around the value do not belong to it
+	consumed := next - 1
+	for len(extra) > 0 && extra[len(extra)-1] == "" {
+		extra = extra[:len(extra)-1]
+	}
+	for len(extra) > 0 && extra[0] == "" {
+		extra = extra[1:]
+	}
+	if len(extra) == 0 {
+		if block {
+			return "", consumed
+		}
+		return first, consumed
+	}
+
+	if !block {
+		extra = dedent(extra)
+		if first != "" {
+			extra = append([]string{first}, extra...)
+		}
+	}
+	return strings.Join(extra, "\n"), consumed
+}
+
+// dedent removes the indentation common to all non-blank lines
+func dedent(lines []string) []string {
+	indent := -1
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		if n := len(line) - len(strings.TrimLeft(line, " ")); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		if len(line) >= indent {
+			result[i] = line[indent:]
+		}
+	}
+	return result
+}
+
+// attachAnnotations assigns job and step annotations to the jobs and steps
+// of the layout by position.
+//
+// An annotation inside the body of a job or step item belongs to it; an
+// annotation placed before a job key or step item belongs to the next job
+// key or step item. Annotations that cannot be attached, and annotations that
+// override an earlier one of the same job or step, are passed to report.
+func attachAnnotations(layout *workflowLayout, annotations []annotation, report func(a annotation, rule, format string, args ...any)) {
+	// Line of the first annotation of each field, per job or step
+	seen := make(map[any]map[string]int)
+	attached := func(target any, a annotation) {
+		if seen[target] == nil {
+			seen[target] = make(map[string]int)
+		}
+		if line, ok := seen[target][a.field]; ok {
+			report(a, RuleDuplicateAnnotation, "duplicate annotation @%s.%s overrides the one on line %d", a.kind, a.field, line)
+			return
+		}
+		seen[target][a.field] = a.line
+	}
+
+	for _, a := range annotations {
+		job := layout.jobAt(a)
+		if job == nil {
+			report(a, RuleUnattachedAnnotation, "@%s.%s is not attached to any %s", a.kind, a.field, a.kind)
+			continue
+		}
+		if a.kind == "job" {
+			attached(job.job, a)
+			job.job.set(a.field, a.value)
+			continue
+		}
+		step := job.stepAt(a)
+		if step == nil {
+			report(a, RuleUnattachedAnnotation, "@%s.%s is not attached to any %s", a.kind, a.field, a.kind)
+			continue
+		}
+		attached(step, a)
+		step.set(a.field, a.value)
+	}
+}
+
+// jobAt returns the job an annotation belongs to, or nil
+func (l *workflowLayout) jobAt(a annotation) *jobPosition {
+	if a.line <= l.jobsLine {
+		return nil
+	}
+	for i, job := range l.jobs {
+		if job.line > a.line {
+			// Annotation above this job key; it belongs to this job unless it
+			// is indented into the body of the previous job
+			if i > 0 && a.column > l.jobs[i-1].column {
+				return l.jobs[i-1]
+			}
+			if a.kind == "step" {
+				return nil
+			}
+			return job
+		}
+	}
+	if n := len(l.jobs); n > 0 && a.column > l.jobs[n-1].column {
+		return l.jobs[n-1]
+	}
+	return nil
+}
+
+// stepAt returns the step of the job an annotation belongs to, or nil
+func (j *jobPosition) stepAt(a annotation) *StepDoc {
+	for i, step := range j.steps {
+		if step.line > a.line {
+			// Annotation above this step item; it belongs to this step unless
+			// it is indented into the body of the previous step
+			if i > 0 && a.column > j.stepColumn {
+				return j.steps[i-1].step
+			}
+			return step.step
+		}
+	}
+	if n := len(j.steps); n > 0 && a.column > j.stepColumn {
+		return j.steps[n-1].step
+	}
+	return nil
+}
+
+// nearMiss explains why a comment that looks like an annotation of one of
+// kinds is not one, or returns "" if it does not look like one. trimmed is the
+// comment without its indentation, which ends in column. The first kind is
+// the top-level kind, which must not be indented.
+func nearMiss(trimmed string, column int, kinds []string) string {
+	matches := nearMissPattern.FindStringSubmatch(trimmed)
+	if matches == nil || !slices.Contains(kinds, strings.ToLower(matches[1])) {
+		return ""
+	}
+	written := "@" + matches[1] + "." + matches[2]
+	lower := strings.ToLower(written)
+	switch {
+	case !strings.HasPrefix(trimmed, "# @"):
+		return fmt.Sprintf("annotations start with \"# @\", found %q", strings.TrimSuffix(trimmed[:len(matches[0])], ":"))
+	case matches[2] == "":
+		return fmt.Sprintf("annotation %s has no key", written)
+	case written != lower:
+		return fmt.Sprintf("annotation keys are lowercase, use %s instead of %s", lower, written)
+	case matches[3] == "":
+		return fmt.Sprintf("missing colon after %s", written)
+	case matches[1] == kinds[0] && column > 1:
+		return fmt.Sprintf("%s must not be indented", written)
+	}
+	return fmt.Sprintf("malformed annotation %s", written)
+}
+
+// set assigns a workflow annotation field, ignoring unknown fields
+func (d *WorkflowDoc) set(field, value string) {
+	switch field {
+	case "name":
+		d.Name = value
+	case "description":
+		d.Description = value
+	case "owners":
+		d.Owners = value
+		d.OwnerList = parseOwners(value)
+	case "tags":
+		d.Tags = value
+		d.TagList = parseList(value)
+	case "params":
+		d.Params = value
+	case "results":
+		d.Results = value
+	case "permissions":
+		d.Permissions = value
+	case "requirements":
+		d.Requirements = value
+	case "triggers":
+		d.TriggersNote = value
+	}
+}
+
+// set assigns a job annotation field, ignoring unknown fields
+func (j *JobDoc) set(field, value string) {
+	switch field {
+	case "name":
+		j.Name = value
+	case "description":
+		j.Description = value
+	case "owners":
+		j.Owners = value
+		j.OwnerList = parseOwners(value)
+	case "permissions":
+		j.Permissions = value
+	case "requirements":
+		j.Requirements = value
+	}
+}
+
+// set assigns a step annotation field, ignoring unknown fields
+func (s *StepDoc) set(field, value string) {
+	switch field {
+	case "name":
+		s.Name = value
+	case "description":
+		s.Description = value
+	}
+}
+
+// Label returns the best available display label of the step
+func (s *StepDoc) Label() string {
+	switch {
+	case s.Name != "":
+		return s.Name
+	case s.ID != "":
+		return s.ID
+	case s.Uses != "":
+		return s.Uses
+	}
+	return fmt.Sprintf("Step %d", s.Index)
 }
 
 // ParseWorkflowFile parses a workflow YAML file and extracts documentation comments
-func ParseWorkflowFile(filePath string) (doc *WorkflowDoc, err error) {
+func ParseWorkflowFile(filePath string) (*WorkflowDoc, error) {
+	return ParseWorkflowFileWithOptions(filePath, ParseOptions{})
+}
+
+// ParseWorkflowFileWithOptions parses a workflow YAML file using the given options
+func ParseWorkflowFileWithOptions(filePath string, opts ParseOptions) (doc *WorkflowDoc, err error) {
 	// Validate and clean the file path to prevent directory traversal
 	cleanPath := filepath.Clean(filePath)
 
@@ -44,113 +473,209 @@ func ParseWorkflowFile(filePath string) (doc *WorkflowDoc, err error) {
 		FileName: filepath.Base(filePath),
 	}
 
-	// Regex patterns to match documentation comments
-	workflowPattern := regexp.MustCompile(`^#\s*@workflow\.([a-z]+):\s*(.*)$`)
-	jobPattern := regexp.MustCompile(`^#\s*@job\.([a-z]+):\s*(.*)$`)
-	stepPattern := regexp.MustCompile(`^#\s*@step\.([a-z]+):\s*(.*)$`)
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		// Only process lines starting with # @
-		if !strings.HasPrefix(strings.TrimSpace(line), "# @") {
-			continue
-		}
+	content, rerr := io.ReadAll(file)
+	if rerr != nil {
+		return nil, rerr
+	}
 
-		// Try to match workflow pattern
-		matches := workflowPattern.FindStringSubmatch(line)
-		if len(matches) == 3 {
-			field := matches[1]
-			value := strings.TrimSpace(matches[2])
-
-			switch field {
-			case "name":
-				doc.Name = value
-			case "description":
-				doc.Description = value
-			case "owners":
-				doc.Owners = value
-			case "tags":
-				doc.Tags = value
-			case "params":
-				doc.Params = value
-			case "results":
-				doc.Results = value
-			case "permissions":
-				doc.Permissions = value
-			case "requirements":
-				doc.Requirements = value
-			}
-			continue
-		}
+	scanned, serr := annotationScanner{
+		file:       filePath,
+		kinds:      workflowKinds,
+		fields:     workflowFields,
+		customKeys: opts.CustomKeys,
+		nested:     map[string][]string{"job": jobFields, "step": stepFields},
+		noteKinds:  []string{"param", "output", "secret"},
+	}.scan(content)
+	if serr != nil {
+		return nil, serr
+	}
+	for _, a := range scanned.fields {
+		doc.set(a.field, a.value)
+	}
+	doc.Extra = scanned.extra
+	doc.Positions = scanned.positions
 
-		// Try to match job pattern (for future expansion)
-		jobMatches := jobPattern.FindStringSubmatch(line)
-		if len(jobMatches) == 3 {
-			// Job-level documentation - not yet stored in WorkflowDoc
-			// This is parsed for validation but not currently used
-			continue
-		}
+	// Populate the document from the workflow definition itself; annotations
+	// only enrich or override what the YAML declares
+	layout, yerr := parseWorkflowYAML(doc, content)
+	if yerr != nil {
+		// Without jobs, steps and declarations to attach annotations to,
+		// reporting them as unattached would hide the actual problem
+		scanned.diagnostics = append(scanned.diagnostics, yamlDiagnostic(filePath, yerr))
+	} else {
+		attachAnnotations(layout, scanned.nested, func(a annotation, rule, format string, args ...any) {
+			scanned.report(filePath, a.line, a.column, rule, format, args...)
+		})
+		scanned.checkNotes(filePath, doc.declares)
+	}
+	doc.Diagnostics = scanned.diagnostics
+	checkWorkflowRefs(doc)
+	sortDiagnostics(doc.Diagnostics)
 
-		// Try to match step pattern (for future expansion)
-		stepMatches := stepPattern.FindStringSubmatch(line)
-		if len(stepMatches) == 3 {
-			// Step-level documentation - not yet stored in WorkflowDoc
-			// This is parsed for validation but not currently used
-			continue
-		}
+	for i := range doc.Inputs {
+		doc.Inputs[i].Description = mergeDescription(doc.Inputs[i].Description, scanned.notes["param."+doc.Inputs[i].Name])
+	}
+	for i := range doc.Outputs {
+		doc.Outputs[i].Description = mergeDescription(doc.Outputs[i].Description, scanned.notes["output."+doc.Outputs[i].Name])
+	}
+	for i := range doc.Secrets {
+		doc.Secrets[i].Description = mergeDescription(doc.Secrets[i].Description, scanned.notes["secret."+doc.Secrets[i].Name])
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	switch {
+	case doc.Name != "":
+		doc.NameSource = NameSourceAnnotation
+	case doc.DeclaredName != "":
+		doc.NameSource = NameSourceDeclared
 	}
 
 	return doc, nil
 }
 
+// noteTargets names what the notes of each kind describe
+var noteTargets = map[string]string{"param": "input", "output": "output", "secret": "secret"}
+
+// declares reports whether the workflow declares the input, output or secret
+// that a note of the given kind refers to
+func (d *WorkflowDoc) declares(kind, name string) bool {
+	switch kind {
+	case "param":
+		return slices.ContainsFunc(d.Inputs, func(p Param) bool { return p.Name == name })
+	case "output":
+		return slices.ContainsFunc(d.Outputs, func(o Output) bool { return o.Name == name })
+	case "secret":
+		return slices.ContainsFunc(d.Secrets, func(s Secret) bool { return s.Name == name })
+	}
+	return false
+}
+
+// yamlErrorLine matches the line number in the errors of the YAML decoder
+var yamlErrorLine = regexp.MustCompile(`line (\d+): (.*)`)
+
+// yamlDiagnostic reports a workflow file that is not valid YAML, at the line
+// of the error if the decoder gives one
+func yamlDiagnostic(filePath string, err error) Diagnostic {
+	diagnostic := Diagnostic{
+		File:     filePath,
+		Line:     1,
+		Column:   1,
+		Severity: SeverityError,
+		Rule:     RuleInvalidYAML,
+		Message:  "invalid YAML: " + strings.TrimPrefix(err.Error(), "yaml: "),
+	}
+	if matches := yamlErrorLine.FindStringSubmatch(err.Error()); matches != nil {
+		diagnostic.Line, _ = strconv.Atoi(matches[1])
+		diagnostic.Message = "invalid YAML: " + matches[2]
+	}
+	return diagnostic
+}
+
 // ParseWorkflowsDirectory parses all workflow files in a directory
 func ParseWorkflowsDirectory(dirPath string) ([]*WorkflowDoc, error) {
+	return ParseWorkflowsDirectoryWithOptions(dirPath, ParseOptions{})
+}
+
+// ParseWorkflowsDirectoryWithOptions parses all workflow files in a directory
+// using the given options. Files are returned in lexical order of their path.
+func ParseWorkflowsDirectoryWithOptions(dirPath string, opts ParseOptions) ([]*WorkflowDoc, error) {
+	return ParseWorkflowsDirectories([]string{dirPath}, opts)
+}
+
+// ParseWorkflowsDirectories parses the workflow files of several directories.
+// Files are returned in the order of the directories and in lexical order of
+// their path within each directory; a file that is found through more than
+// one directory is parsed once, for the first.
+func ParseWorkflowsDirectories(dirPaths []string, opts ParseOptions) ([]*WorkflowDoc, error) {
 	var docs []*WorkflowDoc
+	seen := make(map[string]bool)
 
-	// Clean and validate the directory path
-	cleanDirPath := filepath.Clean(dirPath)
+	for _, dirPath := range dirPaths {
+		files, err := workflowFiles(dirPath, opts)
+		if err != nil {
+			return nil, err
+		}
 
-	// Find all YAML files in the directory
-	files, err := filepath.Glob(filepath.Join(cleanDirPath, "*.yml"))
-	if err != nil {
-		return nil, err
+		for _, file := range files {
+			abs, err := filepath.Abs(file)
+			if err != nil {
+				return nil, err
+			}
+			if seen[abs] {
+				continue
+			}
+			seen[abs] = true
+
+			doc, err := ParseWorkflowFileWithOptions(file, opts)
+			if err != nil {
+				slog.Warn("Failed to parse workflow file", "file", file, "error", err)
+				continue
+			}
+			docs = append(docs, doc)
+		}
 	}
 
-	yamlFiles, err := filepath.Glob(filepath.Join(cleanDirPath, "*.yaml"))
-	if err != nil {
+	return docs, nil
+}
+
+// workflowFiles returns the YAML files of a directory that are selected by
+// the options, in lexical order. A missing directory has no files.
+func workflowFiles(dirPath string, opts ParseOptions) ([]string, error) {
+	var files []string
+
+	// Clean and validate the directory path
+	cleanDirPath := filepath.Clean(dirPath)
+	root, err := walkRoot(cleanDirPath)
+	if err != nil || root == "" {
 		return nil, err
 	}
 
-	files = append(files, yamlFiles...)
-
-	for _, file := range files {
-		// Check if file is a symlink and skip it
-		fileInfo, err := os.Lstat(file)
+	err = filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
 		if err != nil {
-			slog.Warn("Failed to stat file", "file", file, "error", err)
-			continue
+			return err
+		}
+
+		if entry.IsDir() {
+			if file != root && (!opts.Recursive || entry.Name() == ".git") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// Action metadata files are not workflows, see ParseActionsDirectory
+		if ext := filepath.Ext(file); ext != ".yml" && ext != ".yaml" || slices.Contains(actionFileNames, entry.Name()) {
+			return nil
 		}
 
-		if fileInfo.Mode()&os.ModeSymlink != 0 {
+		// Check if file is a symlink and skip it
+		if entry.Type()&os.ModeSymlink != 0 {
 			slog.Warn("Skipping symlink", "file", file)
-			continue
+			return nil
 		}
 
-		doc, err := ParseWorkflowFile(file)
+		rel, err := filepath.Rel(root, file)
 		if err != nil {
-			slog.Warn("Failed to parse workflow file", "file", file, "error", err)
-			continue
+			return err
+		}
+		if !selectPath(filepath.ToSlash(rel), opts.Include, opts.Exclude) {
+			slog.Debug("Skipping excluded file", "file", file)
+			return nil
 		}
-		docs = append(docs, doc)
-	}
 
-	return docs, nil
+		files = append(files, filepath.Join(cleanDirPath, rel))
+		return nil
+	})
+	return files, err
+}
+
+// walkRoot returns the directory to walk for dirPath. filepath.WalkDir does
+// not follow a symlinked root, so it is resolved first; symlinks below the
+// root are still skipped. A missing directory has no root.
+func walkRoot(dirPath string) (string, error) {
+	root, err := filepath.EvalSymlinks(dirPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return root, err
 }
 
 // escapeMarkdown escapes special markdown characters in table cells
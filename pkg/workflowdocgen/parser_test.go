@@ -1,8 +1,13 @@
 package workflowdocgen
 
 import (
+	"bytes"
+	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -45,6 +50,12 @@ on: push
 		if doc.Tags != "ci, testing" {
 			t.Errorf("Expected tags 'ci, testing', got '%s'", doc.Tags)
 		}
+		if len(doc.TagList) != 2 || doc.TagList[0] != "ci" || doc.TagList[1] != "testing" {
+			t.Errorf("Expected tag list [ci testing], got %v", doc.TagList)
+		}
+		if len(doc.OwnerList) != 1 || doc.OwnerList[0] != "team-platform" {
+			t.Errorf("Expected owner list [team-platform], got %v", doc.OwnerList)
+		}
 		if doc.Params != "branch, environment" {
 			t.Errorf("Expected params 'branch, environment', got '%s'", doc.Params)
 		}
@@ -320,6 +331,134 @@ name: Valid`,
 		}
 	})
 
+	t.Run("recursive with include and exclude", func(t *testing.T) {
+		root := filepath.Join(tempDir, "monorepo")
+		files := []string{
+			"ci.yml",
+			"templates/deploy.yml",
+			"templates/go/build.yaml",
+			"templates/experimental/try.yml",
+			"actions/setup/action.yml",
+			".git/config.yml",
+		}
+		for _, name := range files {
+			filePath := filepath.Join(root, name)
+			if err := os.MkdirAll(filepath.Dir(filePath), 0750); err != nil { // #nosec G301 - test directory
+				t.Fatalf("Failed to create directory: %v", err)
+			}
+			if err := os.WriteFile(filePath, []byte("name: "+name+"\n"), 0600); err != nil { // #nosec G306 - test file
+				t.Fatalf("Failed to create file %s: %v", name, err)
+			}
+		}
+
+		relPaths := func(docs []*WorkflowDoc) []string {
+			var paths []string
+			for _, doc := range docs {
+				rel, err := filepath.Rel(root, doc.FilePath)
+				if err != nil {
+					t.Fatalf("Failed to make path relative: %v", err)
+				}
+				paths = append(paths, filepath.ToSlash(rel))
+			}
+			return paths
+		}
+
+		docs, err := ParseWorkflowsDirectory(root)
+		if err != nil {
+			t.Fatalf("ParseWorkflowsDirectory failed: %v", err)
+		}
+		if got := relPaths(docs); !slices.Equal(got, []string{"ci.yml"}) {
+			t.Errorf("Expected only top-level files without recursion, got %v", got)
+		}
+
+		docs, err = ParseWorkflowsDirectoryWithOptions(root, ParseOptions{Recursive: true})
+		if err != nil {
+			t.Fatalf("ParseWorkflowsDirectoryWithOptions failed: %v", err)
+		}
+		// action.yml files are actions, not workflows
+		want := []string{"ci.yml", "templates/deploy.yml", "templates/experimental/try.yml", "templates/go/build.yaml"}
+		if got := relPaths(docs); !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+
+		docs, err = ParseWorkflowsDirectoryWithOptions(root, ParseOptions{
+			Recursive: true,
+			Include:   []string{"templates/**"},
+			Exclude:   []string{"templates/experimental/*"},
+		})
+		if err != nil {
+			t.Fatalf("ParseWorkflowsDirectoryWithOptions failed: %v", err)
+		}
+		want = []string{"templates/deploy.yml", "templates/go/build.yaml"}
+		if got := relPaths(docs); !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("multiple directories", func(t *testing.T) {
+		root := filepath.Join(tempDir, "roots")
+		for _, name := range []string{"b/ci.yml", "a/release.yml", "a/deploy.yml"} {
+			filePath := filepath.Join(root, name)
+			if err := os.MkdirAll(filepath.Dir(filePath), 0750); err != nil { // #nosec G301 - test directory
+				t.Fatalf("Failed to create directory: %v", err)
+			}
+			if err := os.WriteFile(filePath, []byte("name: "+name+"\n"), 0600); err != nil { // #nosec G306 - test file
+				t.Fatalf("Failed to create file %s: %v", name, err)
+			}
+		}
+
+		// The root directory overlaps with both others
+		dirs := []string{filepath.Join(root, "b"), filepath.Join(root, "a"), root, filepath.Join(root, "missing")}
+		docs, err := ParseWorkflowsDirectories(dirs, ParseOptions{Recursive: true})
+		if err != nil {
+			t.Fatalf("ParseWorkflowsDirectories failed: %v", err)
+		}
+
+		var got []string
+		for _, doc := range docs {
+			got = append(got, doc.DeclaredName)
+		}
+		want := []string{"b/ci.yml", "a/deploy.yml", "a/release.yml"}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("symlinked directory", func(t *testing.T) {
+		target := filepath.Join(tempDir, "target")
+		for name, content := range map[string]string{"ci.yml": "name: CI\n", "shared.yml": "name: Shared\n"} {
+			filePath := filepath.Join(target, name)
+			if err := os.MkdirAll(target, 0750); err != nil { // #nosec G301 - test directory
+				t.Fatalf("Failed to create directory: %v", err)
+			}
+			if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+				t.Fatalf("Failed to create file %s: %v", name, err)
+			}
+		}
+		link := filepath.Join(tempDir, "link")
+		if err := os.Symlink(target, link); err != nil {
+			t.Skipf("Symlinks are not supported: %v", err)
+		}
+		// Symlinked files below the root are still skipped
+		if err := os.Symlink(filepath.Join(target, "shared.yml"), filepath.Join(target, "alias.yml")); err != nil {
+			t.Fatalf("Failed to create symlink: %v", err)
+		}
+
+		docs, err := ParseWorkflowsDirectory(link)
+		if err != nil {
+			t.Fatalf("ParseWorkflowsDirectory failed: %v", err)
+		}
+
+		var got []string
+		for _, doc := range docs {
+			got = append(got, doc.FilePath)
+		}
+		want := []string{filepath.Join(link, "ci.yml"), filepath.Join(link, "shared.yml")}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
 	t.Run("non-existent directory", func(t *testing.T) {
 		_, err := ParseWorkflowsDirectory("/nonexistent/directory")
 		// Should not return error for glob on non-existent directory
@@ -329,3 +468,646 @@ name: Valid`,
 		}
 	})
 }
+
+func TestParseWorkflowFileJobs(t *testing.T) {
+	tempDir := t.TempDir()
+
+	t.Run("job annotations inside and above job keys", func(t *testing.T) {
+		content := `# @workflow.name: CI
+name: CI
+on: push
+
+jobs:
+  test:
+    # @job.description: Run unit tests
+    # @job.owners: team-qa
+    runs-on: ubuntu-latest
+    steps:
+      - run: go test ./...
+
+  # @job.name: Build binaries
+  # @job.description: Build all platforms
+  build:
+    runs-on: ubuntu-latest
+    needs: test
+    steps:
+      - run: go build ./...
+
+  plain:
+    runs-on: ubuntu-latest
+`
+		filePath := filepath.Join(tempDir, "jobs.yml")
+		if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+
+		doc, err := ParseWorkflowFile(filePath)
+		if err != nil {
+			t.Fatalf("ParseWorkflowFile failed: %v", err)
+		}
+
+		if len(doc.Jobs) != 3 {
+			t.Fatalf("Expected 3 jobs, got %d", len(doc.Jobs))
+		}
+
+		test := doc.Jobs[0]
+		if test.ID != "test" {
+			t.Errorf("Expected job ID 'test', got '%s'", test.ID)
+		}
+		if test.Description != "Run unit tests" {
+			t.Errorf("Expected description 'Run unit tests', got '%s'", test.Description)
+		}
+		if test.Owners != "team-qa" {
+			t.Errorf("Expected owners 'team-qa', got '%s'", test.Owners)
+		}
+
+		build := doc.Jobs[1]
+		if build.ID != "build" {
+			t.Errorf("Expected job ID 'build', got '%s'", build.ID)
+		}
+		if build.Name != "Build binaries" {
+			t.Errorf("Expected name 'Build binaries', got '%s'", build.Name)
+		}
+		if build.Description != "Build all platforms" {
+			t.Errorf("Expected description 'Build all platforms', got '%s'", build.Description)
+		}
+
+		plain := doc.Jobs[2]
+		if plain.ID != "plain" || plain.Description != "" {
+			t.Errorf("Expected undocumented job 'plain', got %+v", plain)
+		}
+	})
+
+	t.Run("workflow without jobs", func(t *testing.T) {
+		content := `# @job.description: Dangling annotation
+name: Test
+on: push
+`
+		filePath := filepath.Join(tempDir, "nojobs.yml")
+		if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+
+		doc, err := ParseWorkflowFile(filePath)
+		if err != nil {
+			t.Fatalf("ParseWorkflowFile failed: %v", err)
+		}
+
+		if len(doc.Jobs) != 0 {
+			t.Errorf("Expected no jobs, got %d", len(doc.Jobs))
+		}
+	})
+}
+
+func TestParseWorkflowFileSteps(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `name: Release
+on: push
+
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    steps:
+    - name: Checkout code
+      uses: actions/checkout@v6
+      with:
+        name: not-a-step-name
+      # @step.description: Checkout the repository code
+
+    # @step.name: Build everything
+    # @step.description: Compile all binaries
+    - id: build
+      run: go build ./...
+
+    - uses: actions/upload-artifact@v5
+
+  notify:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo done
+        # @step.description: Say goodbye
+`
+	filePath := filepath.Join(tempDir, "steps.yml")
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	doc, err := ParseWorkflowFile(filePath)
+	if err != nil {
+		t.Fatalf("ParseWorkflowFile failed: %v", err)
+	}
+
+	if len(doc.Jobs) != 2 {
+		t.Fatalf("Expected 2 jobs, got %d", len(doc.Jobs))
+	}
+
+	steps := doc.Jobs[0].Steps
+	if len(steps) != 3 {
+		t.Fatalf("Expected 3 steps in 'release', got %d", len(steps))
+	}
+
+	if steps[0].Name != "Checkout code" {
+		t.Errorf("Expected step name 'Checkout code', got '%s'", steps[0].Name)
+	}
+	if steps[0].Uses != "actions/checkout@v6" {
+		t.Errorf("Expected step uses 'actions/checkout@v6', got '%s'", steps[0].Uses)
+	}
+	if steps[0].Description != "Checkout the repository code" {
+		t.Errorf("Expected description on first step, got '%s'", steps[0].Description)
+	}
+
+	if steps[1].ID != "build" {
+		t.Errorf("Expected step ID 'build', got '%s'", steps[1].ID)
+	}
+	if steps[1].Name != "Build everything" {
+		t.Errorf("Expected annotated step name 'Build everything', got '%s'", steps[1].Name)
+	}
+	if steps[1].Description != "Compile all binaries" {
+		t.Errorf("Expected description on second step, got '%s'", steps[1].Description)
+	}
+
+	if steps[2].Index != 3 || steps[2].Label() != "actions/upload-artifact@v5" {
+		t.Errorf("Expected third step labelled by uses, got %+v", steps[2])
+	}
+
+	notify := doc.Jobs[1].Steps
+	if len(notify) != 1 {
+		t.Fatalf("Expected 1 step in 'notify', got %d", len(notify))
+	}
+	if notify[0].Description != "Say goodbye" {
+		t.Errorf("Expected description 'Say goodbye', got '%s'", notify[0].Description)
+	}
+	if notify[0].Label() != "Step 1" {
+		t.Errorf("Expected fallback label 'Step 1', got '%s'", notify[0].Label())
+	}
+}
+
+func TestParseWorkflowFileStructure(t *testing.T) {
+	tempDir := t.TempDir()
+
+	t.Run("jobs and triggers from the workflow definition", func(t *testing.T) {
+		content := `name: Pipeline
+on:
+  push:
+    branches: [main]
+  workflow_dispatch:
+
+jobs:
+  lint: {runs-on: ubuntu-latest, steps: [{run: make lint}]}
+  test:
+    name: Unit tests
+    # @job.name: Tests on all platforms
+    runs-on: [self-hosted, linux]
+    needs: lint
+    if: github.event_name == 'push'
+    steps:
+      - run: make test
+  deploy:
+    needs: [lint, test]
+    uses: ./.github/workflows/deploy.yml
+`
+		filePath := filepath.Join(tempDir, "structure.yml")
+		if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+
+		doc, err := ParseWorkflowFile(filePath)
+		if err != nil {
+			t.Fatalf("ParseWorkflowFile failed: %v", err)
+		}
+
+		if len(doc.Triggers) != 2 || doc.Triggers[0].Event != "push" || doc.Triggers[1].Event != "workflow_dispatch" {
+			t.Errorf("Expected triggers [push workflow_dispatch], got %+v", doc.Triggers)
+		}
+
+		if len(doc.Jobs) != 3 {
+			t.Fatalf("Expected 3 jobs, got %d", len(doc.Jobs))
+		}
+
+		lint := doc.Jobs[0]
+		if lint.ID != "lint" || lint.RunsOn != "ubuntu-latest" || len(lint.Steps) != 1 {
+			t.Errorf("Expected flow-style job 'lint' with one step, got %+v", lint)
+		}
+
+		test := doc.Jobs[1]
+		if test.Name != "Tests on all platforms" {
+			t.Errorf("Expected annotation to override job name, got '%s'", test.Name)
+		}
+		if test.RunsOn != "self-hosted, linux" {
+			t.Errorf("Expected runs-on 'self-hosted, linux', got '%s'", test.RunsOn)
+		}
+		if len(test.Needs) != 1 || test.Needs[0] != "lint" {
+			t.Errorf("Expected needs [lint], got %v", test.Needs)
+		}
+		if test.If != "github.event_name == 'push'" {
+			t.Errorf("Expected if condition, got '%s'", test.If)
+		}
+
+		deploy := doc.Jobs[2]
+		if len(deploy.Needs) != 2 {
+			t.Errorf("Expected 2 needs for 'deploy', got %v", deploy.Needs)
+		}
+		if deploy.Uses != "./.github/workflows/deploy.yml" {
+			t.Errorf("Expected uses for 'deploy', got '%s'", deploy.Uses)
+		}
+	})
+
+	t.Run("scalar and list triggers", func(t *testing.T) {
+		content := `on: [push, pull_request]
+`
+		filePath := filepath.Join(tempDir, "list.yml")
+		if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+
+		doc, err := ParseWorkflowFile(filePath)
+		if err != nil {
+			t.Fatalf("ParseWorkflowFile failed: %v", err)
+		}
+
+		if len(doc.Triggers) != 2 || doc.Triggers[1].Event != "pull_request" {
+			t.Errorf("Expected triggers [push pull_request], got %+v", doc.Triggers)
+		}
+	})
+
+	t.Run("invalid YAML keeps annotations", func(t *testing.T) {
+		content := `# @workflow.name: Broken
+name: [unterminated
+`
+		filePath := filepath.Join(tempDir, "broken.yml")
+		if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+
+		doc, err := ParseWorkflowFile(filePath)
+		if err != nil {
+			t.Fatalf("ParseWorkflowFile failed: %v", err)
+		}
+
+		if doc.Name != "Broken" {
+			t.Errorf("Expected name 'Broken', got '%s'", doc.Name)
+		}
+		if len(doc.Jobs) != 0 {
+			t.Errorf("Expected no jobs for invalid YAML, got %d", len(doc.Jobs))
+		}
+	})
+}
+
+func TestParseWorkflowFileNames(t *testing.T) {
+	tempDir := t.TempDir()
+
+	tests := []struct {
+		name         string
+		content      string
+		wantName     string
+		wantDeclared string
+		wantDisplay  string
+		wantSource   NameSource
+		wantMismatch bool
+	}{
+		{
+			name:         "annotated and declared names match",
+			content:      "# @workflow.name: CI\nname: CI\n",
+			wantName:     "CI",
+			wantDeclared: "CI",
+			wantDisplay:  "CI",
+			wantSource:   NameSourceAnnotation,
+		},
+		{
+			name:         "annotated and declared names differ",
+			content:      "# @workflow.name: CI Pipeline\nname: CI\n",
+			wantName:     "CI Pipeline",
+			wantDeclared: "CI",
+			wantDisplay:  "CI Pipeline",
+			wantSource:   NameSourceAnnotation,
+			wantMismatch: true,
+		},
+		{
+			name:         "declared name only",
+			content:      "name: Release\non: push\n",
+			wantDeclared: "Release",
+			wantDisplay:  "Release",
+			wantSource:   NameSourceDeclared,
+		},
+		{
+			name:       "no name at all",
+			content:    "on: push\n",
+			wantSource: NameSourceNone,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(tempDir, fmt.Sprintf("names%d.yml", i))
+			if err := os.WriteFile(filePath, []byte(tt.content), 0600); err != nil { // #nosec G306 - test file
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			doc, err := ParseWorkflowFile(filePath)
+			if err != nil {
+				t.Fatalf("ParseWorkflowFile failed: %v", err)
+			}
+
+			if doc.Name != tt.wantName {
+				t.Errorf("Expected name '%s', got '%s'", tt.wantName, doc.Name)
+			}
+			if doc.DeclaredName != tt.wantDeclared {
+				t.Errorf("Expected declared name '%s', got '%s'", tt.wantDeclared, doc.DeclaredName)
+			}
+			if doc.DisplayName() != tt.wantDisplay {
+				t.Errorf("Expected display name '%s', got '%s'", tt.wantDisplay, doc.DisplayName())
+			}
+			if doc.NameSource != tt.wantSource {
+				t.Errorf("Expected name source '%s', got '%s'", tt.wantSource, doc.NameSource)
+			}
+			if doc.NameMismatch() != tt.wantMismatch {
+				t.Errorf("Expected name mismatch %v, got %v", tt.wantMismatch, doc.NameMismatch())
+			}
+		})
+	}
+}
+
+func TestParseWorkflowFileExtra(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `# @workflow.name: CI
+# @workflow.outputs: Build artifacts
+# @workflow.slack-channel: #ci-alerts
+# @workflow.cost_center: 1234
+name: CI
+`
+	filePath := filepath.Join(tempDir, "extra.yml")
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer slog.SetDefault(previous)
+
+	doc, err := ParseWorkflowFileWithOptions(filePath, ParseOptions{CustomKeys: []string{"slack-channel"}})
+	if err != nil {
+		t.Fatalf("ParseWorkflowFileWithOptions failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"outputs":       "Build artifacts",
+		"slack-channel": "#ci-alerts",
+		"cost_center":   "1234",
+	}
+	if len(doc.Extra) != len(expected) {
+		t.Errorf("Expected %d extra fields, got %v", len(expected), doc.Extra)
+	}
+	for key, want := range expected {
+		if got := doc.Extra[key]; got != want {
+			t.Errorf("Expected extra field '%s' to be '%s', got '%s'", key, want, got)
+		}
+	}
+	if _, ok := doc.Extra["name"]; ok {
+		t.Error("Built-in fields should not be stored in Extra")
+	}
+
+	output := logs.String()
+	if !strings.Contains(output, "key=@workflow.outputs") || !strings.Contains(output, "key=@workflow.cost_center") {
+		t.Errorf("Expected warnings for unknown keys, got:\n%s", output)
+	}
+	if strings.Contains(output, "slack-channel") {
+		t.Errorf("Did not expect a warning for an allowed custom key, got:\n%s", output)
+	}
+}
+
+func TestParseWorkflowFileMultiLine(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `# @workflow.description: Builds and tests the project.
+#   Runs on every push.
+#
+#   - lint
+#   - test
+# @workflow.requirements: |
+# Secrets:
+#   - CODECOV_TOKEN
+#
+# Variables are optional.
+# @workflow.owners: team-platform
+# A regular comment that is not part of any value
+name: CI
+on: push
+jobs:
+  test:
+    # @job.description:
+    #   First line
+    #   second line
+    runs-on: ubuntu-latest
+    steps:
+      - run: make test
+        # @step.description: Run the tests
+        #   with the race detector
+        # @step.name: Not a continuation
+`
+	filePath := filepath.Join(tempDir, "multiline.yml")
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	doc, err := ParseWorkflowFile(filePath)
+	if err != nil {
+		t.Fatalf("ParseWorkflowFile failed: %v", err)
+	}
+
+	wantDescription := "Builds and tests the project.\nRuns on every push.\n\n- lint\n- test"
+	if doc.Description != wantDescription {
+		t.Errorf("Expected description %q, got %q", wantDescription, doc.Description)
+	}
+
+	wantRequirements := "Secrets:\n  - CODECOV_TOKEN\n\nVariables are optional."
+	if doc.Requirements != wantRequirements {
+		t.Errorf("Expected requirements %q, got %q", wantRequirements, doc.Requirements)
+	}
+
+	if doc.Owners != "team-platform" {
+		t.Errorf("Expected owners 'team-platform', got %q", doc.Owners)
+	}
+
+	if len(doc.Jobs) != 1 || len(doc.Jobs[0].Steps) != 1 {
+		t.Fatalf("Expected 1 job with 1 step, got %+v", doc.Jobs)
+	}
+	if doc.Jobs[0].Description != "First line\nsecond line" {
+		t.Errorf("Expected multi-line job description, got %q", doc.Jobs[0].Description)
+	}
+
+	step := doc.Jobs[0].Steps[0]
+	if step.Description != "Run the tests\nwith the race detector" {
+		t.Errorf("Expected multi-line step description, got %q", step.Description)
+	}
+	if step.Name != "Not a continuation" {
+		t.Errorf("Expected step name from separate annotation, got %q", step.Name)
+	}
+}
+
+func TestMissingFields(t *testing.T) {
+	doc := &WorkflowDoc{
+		DeclaredName: "CI",
+		Description:  "Build",
+		Triggers:     []Trigger{{Event: "push"}},
+		Extra:        map[string]string{"runbook": "docs/ci.md"},
+	}
+
+	got := doc.MissingFields([]string{"name", "description", "owners", "tags", "triggers", "runbook", "slack-channel"})
+	want := []string{"owners", "tags", "slack-channel"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected missing fields %v, got %v", want, got)
+	}
+
+	if got := (&WorkflowDoc{TriggersNote: "nightly"}).MissingFields([]string{"triggers", "name"}); !slices.Equal(got, []string{"name"}) {
+		t.Errorf("Expected only name to be missing, got %v", got)
+	}
+}
+
+func TestParseWorkflowFileDiagnostics(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `# @workflow.Name: CI
+#@workflow.description: Build
+# @workflow.owners team-ci
+# @workflow.tags: ci
+# @workflow.tags: go
+# @param.target: First
+# @param.target: Second
+# @job.description: Too early
+name: CI
+on:
+  workflow_dispatch:
+    inputs:
+      target:
+        type: string
+jobs:
+  # @job.description: Builds
+  # @job.description: Builds again
+  # @job.owner: team-ci
+  build:
+    runs-on: ubuntu-latest
+    # @workflow.requirements: Docker
+    steps:
+      # @step.description: Checkout
+      - uses: actions/checkout@v4
+      # @Step.description: Test
+      - run: go test ./...
+`
+	filePath := filepath.Join(tempDir, "diagnostics.yml")
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	doc, err := ParseWorkflowFile(filePath)
+	if err != nil {
+		t.Fatalf("ParseWorkflowFile failed: %v", err)
+	}
+
+	var got []string
+	for _, d := range doc.Diagnostics {
+		if d.File != filePath || d.Severity != SeverityWarning {
+			t.Errorf("Expected a warning for %s, got %+v", filePath, d)
+		}
+		got = append(got, fmt.Sprintf("%d:%d %s: %s", d.Line, d.Column, d.Rule, d.Message))
+	}
+	expected := []string{
+		"1:1 malformed-annotation: annotation keys are lowercase, use @workflow.name instead of @workflow.Name",
+		`2:1 malformed-annotation: annotations start with "# @", found "#@workflow.description"`,
+		"3:1 malformed-annotation: missing colon after @workflow.owners",
+		"5:1 duplicate-annotation: duplicate annotation @workflow.tags overrides the one on line 4",
+		"7:1 duplicate-annotation: duplicate annotation @param.target overrides the one on line 6",
+		"8:1 unattached-annotation: @job.description is not attached to any job",
+		"17:3 duplicate-annotation: duplicate annotation @job.description overrides the one on line 16",
+		"18:3 malformed-annotation: unknown annotation @job.owner, expected one of: name, description, owners, permissions, requirements",
+		"21:5 malformed-annotation: @workflow.requirements must not be indented",
+		"25:7 malformed-annotation: annotation keys are lowercase, use @step.description instead of @Step.description",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected diagnostics:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	// The last annotation still wins
+	if doc.Tags != "go" || doc.Jobs[0].Description != "Builds again" || doc.Inputs[0].Description != "Second" {
+		t.Errorf("Expected later annotations to override earlier ones, got tags '%s', job '%s', input '%s'",
+			doc.Tags, doc.Jobs[0].Description, doc.Inputs[0].Description)
+	}
+
+	t.Run("notes of undeclared inputs, outputs and secrets", func(t *testing.T) {
+		path := filepath.Join(tempDir, "notes.yml")
+		content := `# @param.target: Declared
+# @param.tagret: Typo
+# @output.artifact: Undeclared
+# @secret.token: Undeclared
+on:
+  workflow_call:
+    inputs:
+      target:
+        type: string
+jobs: {}
+`
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil { // #nosec G306 - test file
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		doc, err := ParseWorkflowFile(path)
+		if err != nil {
+			t.Fatalf("ParseWorkflowFile failed: %v", err)
+		}
+
+		var got []string
+		for _, d := range doc.Diagnostics {
+			got = append(got, fmt.Sprintf("%d:%d %s: %s", d.Line, d.Column, d.Rule, d.Message))
+		}
+		expected := []string{
+			"2:1 unattached-annotation: @param.tagret does not match any declared input",
+			"3:1 unattached-annotation: @output.artifact does not match any declared output",
+			"4:1 unattached-annotation: @secret.token does not match any declared secret",
+		}
+		if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+			t.Errorf("Expected diagnostics:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+		}
+	})
+
+	t.Run("invalid YAML", func(t *testing.T) {
+		path := filepath.Join(tempDir, "invalid.yml")
+		content := `# @workflow.description: Broken
+# @param.target: Unknown without YAML
+on: push
+jobs:
+  # @job.description: Build
+  build:
+    runs-on: ubuntu-latest
+     steps: []
+`
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil { // #nosec G306 - test file
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		doc, err := ParseWorkflowFile(path)
+		if err != nil {
+			t.Fatalf("ParseWorkflowFile failed: %v", err)
+		}
+
+		if len(doc.Diagnostics) != 1 {
+			t.Fatalf("Expected only the YAML error, got %v", doc.Diagnostics)
+		}
+		d := doc.Diagnostics[0]
+		if d.Line != 8 || d.Severity != SeverityError || d.Rule != RuleInvalidYAML || !strings.HasPrefix(d.Message, "invalid YAML: ") {
+			t.Errorf("Expected an invalid YAML error on line 8, got %+v", d)
+		}
+		if doc.Description != "Broken" {
+			t.Errorf("Expected workflow annotations to be kept, got '%s'", doc.Description)
+		}
+	})
+
+	t.Run("well-formed workflow has no diagnostics", func(t *testing.T) {
+		doc, err := ParseWorkflowFile(filepath.Join("..", "..", ".github", "workflows", "ci.yml"))
+		if err != nil {
+			t.Fatalf("ParseWorkflowFile failed: %v", err)
+		}
+		if len(doc.Diagnostics) != 0 {
+			t.Errorf("Expected no diagnostics, got %v", doc.Diagnostics)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package workflowdocgen
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// PermissionScopes lists the GITHUB_TOKEN permission scopes in display order.
+// The metadata scope is always read and therefore not listed.
+var PermissionScopes = []string{
+	"actions",
+	"attestations",
+	"checks",
+	"contents",
+	"deployments",
+	"discussions",
+	"id-token",
+	"issues",
+	"models",
+	"packages",
+	"pages",
+	"pull-requests",
+	"repository-projects",
+	"security-events",
+	"statuses",
+}
+
+// Permission sources, i.e. where the effective permissions of a job come from
+const (
+	PermissionSourceJob      = "job"
+	PermissionSourceWorkflow = "workflow"
+	PermissionSourceDefault  = "default"
+)
+
+// PermissionSet represents a permissions: block of a workflow or job
+type PermissionSet struct {
+	// Source is one of the PermissionSource constants
+	Source string
+	// Preset is "read-all" or "write-all" when the block is a single keyword
+	Preset string
+	// Scopes maps scope names to "read", "write" or "none"
+	Scopes map[string]string
+}
+
+// parsePermissions reads a permissions: block, or returns nil if there is none
+func parsePermissions(node *yaml.Node, source string) *PermissionSet {
+	if node == nil {
+		return nil
+	}
+
+	set := &PermissionSet{Source: source, Scopes: make(map[string]string)}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		set.Preset = node.Value
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			set.Scopes[node.Content[i].Value] = node.Content[i+1].Value
+		}
+	}
+	return set
+}
+
+// Access returns the access level of a scope: "read", "write", "none", or ""
+// when the repository default applies
+func (p *PermissionSet) Access(scope string) string {
+	if p == nil || p.Source == PermissionSourceDefault {
+		return ""
+	}
+	switch p.Preset {
+	case "read-all":
+		return "read"
+	case "write-all":
+		return "write"
+	}
+	if access, ok := p.Scopes[scope]; ok {
+		return access
+	}
+	// Scopes not listed in an explicit permissions: block get no access
+	return "none"
+}
+
+// EffectivePermissions returns the token permissions a job runs with.
+// A job-level permissions: block replaces the workflow-level block entirely;
+// without either, the repository's default token permissions apply.
+func (d *WorkflowDoc) EffectivePermissions(job *JobDoc) *PermissionSet {
+	switch {
+	case job.DeclaredPermissions != nil:
+		return job.DeclaredPermissions
+	case d.DeclaredPermissions != nil:
+		return d.DeclaredPermissions
+	}
+	return &PermissionSet{Source: PermissionSourceDefault}
+}
@@ -0,0 +1,89 @@
+package workflowdocgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEffectivePermissions(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `name: Permissions
+on: push
+permissions:
+  contents: read
+  pull-requests: write
+jobs:
+  inherit:
+    runs-on: ubuntu-latest
+  override:
+    runs-on: ubuntu-latest
+    permissions:
+      packages: write
+  everything:
+    runs-on: ubuntu-latest
+    permissions: write-all
+  nothing:
+    runs-on: ubuntu-latest
+    permissions: {}
+`
+	filePath := filepath.Join(tempDir, "permissions.yml")
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	doc, err := ParseWorkflowFile(filePath)
+	if err != nil {
+		t.Fatalf("ParseWorkflowFile failed: %v", err)
+	}
+
+	if doc.DeclaredPermissions == nil || doc.DeclaredPermissions.Source != PermissionSourceWorkflow {
+		t.Fatalf("Expected workflow-level permissions, got %+v", doc.DeclaredPermissions)
+	}
+
+	tests := []struct {
+		job    string
+		source string
+		access map[string]string
+	}{
+		{"inherit", PermissionSourceWorkflow, map[string]string{"contents": "read", "pull-requests": "write", "packages": "none"}},
+		{"override", PermissionSourceJob, map[string]string{"contents": "none", "packages": "write"}},
+		{"everything", PermissionSourceJob, map[string]string{"contents": "write", "id-token": "write"}},
+		{"nothing", PermissionSourceJob, map[string]string{"contents": "none", "actions": "none"}},
+	}
+
+	for i, tt := range tests {
+		job := doc.Jobs[i]
+		if job.ID != tt.job {
+			t.Fatalf("Expected job '%s' at index %d, got '%s'", tt.job, i, job.ID)
+		}
+		effective := doc.EffectivePermissions(job)
+		if effective.Source != tt.source {
+			t.Errorf("Job '%s': expected source '%s', got '%s'", tt.job, tt.source, effective.Source)
+		}
+		for scope, want := range tt.access {
+			if got := effective.Access(scope); got != want {
+				t.Errorf("Job '%s': expected %s access '%s', got '%s'", tt.job, scope, want, got)
+			}
+		}
+	}
+}
+
+func TestEffectivePermissionsDefault(t *testing.T) {
+	doc := &WorkflowDoc{}
+	job := &JobDoc{ID: "build"}
+
+	effective := doc.EffectivePermissions(job)
+	if effective.Source != PermissionSourceDefault {
+		t.Errorf("Expected default source, got '%s'", effective.Source)
+	}
+	if got := effective.Access("contents"); got != "" {
+		t.Errorf("Expected no explicit access for default permissions, got '%s'", got)
+	}
+
+	doc.DeclaredPermissions = &PermissionSet{Source: PermissionSourceWorkflow, Preset: "read-all"}
+	if got := doc.EffectivePermissions(job).Access("issues"); got != "read" {
+		t.Errorf("Expected read access from read-all, got '%s'", got)
+	}
+}
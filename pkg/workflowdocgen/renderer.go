@@ -0,0 +1,92 @@
+package workflowdocgen
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// Renderer writes the documentation of a set of workflows in one output format
+type Renderer interface {
+	Render(w io.Writer, docs []*WorkflowDoc) error
+}
+
+// RenderOptions configures the output of the renderers
+type RenderOptions struct {
+	// CustomKeys lists @workflow.* and @action.* keys from the Extra of
+	// workflows and actions that are rendered in the detail section, in this
+	// order
+	CustomKeys []string
+	// Columns selects the columns of the summary table, in this order; see
+	// TableColumns for the names and the default
+	Columns []string
+	// Template replaces the default layout; see ParseMarkdownTemplate
+	Template *template.Template
+	// Actions are the local actions documented after the workflows
+	Actions []*ActionDoc
+}
+
+// renderers maps format names to constructors of the built-in renderers
+var renderers = map[string]func(opts RenderOptions) Renderer{
+	"html":     func(opts RenderOptions) Renderer { return HTMLRenderer{Options: opts} },
+	"json":     func(opts RenderOptions) Renderer { return JSONRenderer{Actions: opts.Actions} },
+	"markdown": func(opts RenderOptions) Renderer { return MarkdownRenderer{Options: opts} },
+	"text":     func(RenderOptions) Renderer { return TextRenderer{} },
+}
+
+// Formats returns the names of the built-in output formats in sorted order
+func Formats() []string {
+	formats := make([]string, 0, len(renderers))
+	for format := range renderers {
+		formats = append(formats, format)
+	}
+	slices.Sort(formats)
+	return formats
+}
+
+// NewRenderer returns the built-in renderer for format. Formats that support
+// custom keys, columns, templates or actions take them from opts.
+func NewRenderer(format string, opts RenderOptions) (Renderer, error) {
+	newRenderer, ok := renderers[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q, expected one of: %s", format, strings.Join(Formats(), ", "))
+	}
+	return newRenderer(opts), nil
+}
+
+// MarkdownRenderer renders the markdown table and detail sections
+type MarkdownRenderer struct {
+	Options RenderOptions
+}
+
+// Render writes the markdown documentation to w
+func (r MarkdownRenderer) Render(w io.Writer, docs []*WorkflowDoc) error {
+	return WriteMarkdownTable(w, docs, r.Options)
+}
+
+// TextRenderer renders a plain-text summary table for terminals
+type TextRenderer struct{}
+
+// Render writes one aligned line per workflow to w
+func (TextRenderer) Render(w io.Writer, docs []*WorkflowDoc) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "WORKFLOW\tOWNERS\tTAGS\tTRIGGERS\tFILE\tDESCRIPTION")
+	for _, doc := range docs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			textCell(doc.DisplayName()),
+			textCell(strings.Join(doc.OwnerHandles(), ", ")),
+			textCell(strings.Join(doc.TagNames(), ", ")),
+			textCell(strings.Join(doc.TriggerEvents(), ", ")),
+			doc.FileName,
+			textCell(doc.Description))
+	}
+	return tw.Flush()
+}
+
+// textCell collapses a value to a single line, or "-" when empty
+func textCell(s string) string {
+	return orDash(inlineText(s))
+}
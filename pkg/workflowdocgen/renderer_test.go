@@ -0,0 +1,85 @@
+package workflowdocgen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewRenderer(t *testing.T) {
+	for _, format := range Formats() {
+		t.Run(format, func(t *testing.T) {
+			renderer, err := NewRenderer(format, RenderOptions{})
+			if err != nil {
+				t.Fatalf("NewRenderer failed: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := renderer.Render(&buf, []*WorkflowDoc{{Name: "CI", FileName: "ci.yml"}}); err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			if !strings.Contains(buf.String(), "ci.yml") {
+				t.Errorf("Expected file name in output, got:\n%s", buf.String())
+			}
+		})
+	}
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := NewRenderer("xml", RenderOptions{})
+		if err == nil {
+			t.Fatal("Expected error for unknown format, got nil")
+		}
+		if !strings.Contains(err.Error(), "markdown, text") {
+			t.Errorf("Expected supported formats in error, got '%s'", err.Error())
+		}
+	})
+}
+
+func TestMarkdownRenderer(t *testing.T) {
+	docs := []*WorkflowDoc{{Name: "CI", Extra: map[string]string{"runbook": "docs/ci.md"}, FileName: "ci.yml"}}
+	opts := RenderOptions{CustomKeys: []string{"runbook"}}
+
+	var buf bytes.Buffer
+	if err := (MarkdownRenderer{Options: opts}).Render(&buf, docs); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	rendered, err := RenderMarkdownTable(docs, opts)
+	if err != nil {
+		t.Fatalf("RenderMarkdownTable failed: %v", err)
+	}
+	if buf.String() != rendered {
+		t.Errorf("Expected renderer output to match RenderMarkdownTable, got:\n%s", buf.String())
+	}
+}
+
+func TestTextRenderer(t *testing.T) {
+	docs := []*WorkflowDoc{
+		{
+			Name:        "CI",
+			Description: "Build and\ntest",
+			OwnerList:   []string{"team-platform"},
+			TagList:     []string{"ci", "go"},
+			FileName:    "ci.yml",
+			Triggers:    []Trigger{{Event: "push"}, {Event: "pull_request"}},
+		},
+		{FileName: "bare.yml"},
+	}
+
+	var buf bytes.Buffer
+	if err := (TextRenderer{}).Render(&buf, docs); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d lines:\n%s", len(lines), buf.String())
+	}
+	if strings.Join(strings.Fields(lines[0]), " ") != "WORKFLOW OWNERS TAGS TRIGGERS FILE DESCRIPTION" {
+		t.Errorf("Unexpected header '%s'", lines[0])
+	}
+	if got := strings.Join(strings.Fields(lines[1]), " "); got != "CI team-platform ci, go push, pull_request ci.yml Build and test" {
+		t.Errorf("Unexpected row '%s'", got)
+	}
+	if got := strings.Join(strings.Fields(lines[2]), " "); got != "- - - - bare.yml -" {
+		t.Errorf("Unexpected row '%s'", got)
+	}
+}
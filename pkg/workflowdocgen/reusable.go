@@ -0,0 +1,153 @@
+package workflowdocgen
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output represents an output declared under on.workflow_call.outputs
+type Output struct {
+	Name        string
+	Description string
+	Value       string
+}
+
+// Secret represents a secret declared under on.workflow_call.secrets
+type Secret struct {
+	Name        string
+	Description string
+	Required    bool
+}
+
+// parseOutputs reads the outputs of the workflow_call event
+func parseOutputs(on *yaml.Node) []Output {
+	outputs := mappingValue(mappingValue(on, "workflow_call"), "outputs")
+	if outputs == nil || outputs.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var result []Output
+	for i := 0; i+1 < len(outputs.Content); i += 2 {
+		config := outputs.Content[i+1]
+		result = append(result, Output{
+			Name:        outputs.Content[i].Value,
+			Description: scalarValue(mappingValue(config, "description")),
+			Value:       scalarValue(mappingValue(config, "value")),
+		})
+	}
+	return result
+}
+
+// parseSecrets reads the secrets of the workflow_call event
+func parseSecrets(on *yaml.Node) []Secret {
+	secrets := mappingValue(mappingValue(on, "workflow_call"), "secrets")
+	if secrets == nil || secrets.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var result []Secret
+	for i := 0; i+1 < len(secrets.Content); i += 2 {
+		config := secrets.Content[i+1]
+		result = append(result, Secret{
+			Name:        secrets.Content[i].Value,
+			Description: scalarValue(mappingValue(config, "description")),
+			Required:    scalarValue(mappingValue(config, "required")) == "true",
+		})
+	}
+	return result
+}
+
+// IsReusable reports whether the workflow can be called by other workflows
+func (d *WorkflowDoc) IsReusable() bool {
+	for _, trigger := range d.Triggers {
+		if trigger.Event == "workflow_call" {
+			return true
+		}
+	}
+	return false
+}
+
+// UsageSnippet returns a YAML job that calls the reusable workflow, with a
+// with: entry per input and a secrets: entry per secret. The repository and
+// ref are left as <owner>/<repo> and <ref> placeholders.
+func (d *WorkflowDoc) UsageSnippet() string {
+	jobID := usageJobID(strings.TrimSuffix(d.FileName, filepath.Ext(d.FileName)))
+
+	var sb strings.Builder
+	sb.WriteString("jobs:\n")
+	sb.WriteString(fmt.Sprintf("  %s:\n", jobID))
+	sb.WriteString(fmt.Sprintf("    uses: <owner>/<repo>/.github/workflows/%s@<ref>\n", d.FileName))
+
+	var inputs []Param
+	for _, input := range d.Inputs {
+		for _, event := range input.Events {
+			if event == "workflow_call" {
+				inputs = append(inputs, input)
+				break
+			}
+		}
+	}
+
+	if len(inputs) > 0 {
+		sb.WriteString("    with:\n")
+		for _, input := range inputs {
+			sb.WriteString(fmt.Sprintf("      %s: %s # %s\n", input.Name, usageValue(input.Default), usageComment(input.Required, input.Type)))
+		}
+	}
+
+	if len(d.Secrets) > 0 {
+		sb.WriteString("    secrets:\n")
+		for _, secret := range d.Secrets {
+			sb.WriteString(fmt.Sprintf("      %s: ${{ secrets.%s }} # %s\n", secret.Name, secret.Name, usageComment(secret.Required, "")))
+		}
+	}
+
+	return sb.String()
+}
+
+// usageJobID turns name into a valid job ID: characters other than letters,
+// digits, - and _ are replaced with -, and an ID that does not start with a
+// letter or _ is prefixed with _
+func usageJobID(name string) string {
+	id := []byte(name)
+	for i, c := range id {
+		if !isJobIDChar(c) {
+			id[i] = '-'
+		}
+	}
+	if len(id) == 0 || !(id[0] == '_' || (id[0]|0x20 >= 'a' && id[0]|0x20 <= 'z')) {
+		id = append([]byte{'_'}, id...)
+	}
+	return string(id)
+}
+
+// isJobIDChar reports whether c may appear in a job ID
+func isJobIDChar(c byte) bool {
+	return c == '-' || c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// usageValue formats a default value for a usage snippet. Values that are
+// empty, span lines, have surrounding spaces or contain YAML indicators are
+// double-quoted.
+func usageValue(value string) string {
+	if value == "" || value != strings.TrimSpace(value) || strings.ContainsAny(value, ":#{}[],&*!|>'\"%@`\n\r\t") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// usageComment describes whether a value is required, and its type if known
+func usageComment(required bool, typ string) string {
+	comment := "optional"
+	if required {
+		comment = "required"
+	}
+	if typ != "" {
+		comment += ", " + typ
+	}
+	return comment
+}
@@ -0,0 +1,164 @@
+package workflowdocgen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseReusableWorkflow(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `name: Build
+on:
+  workflow_call:
+    inputs:
+      go-version:
+        type: string
+        default: '1.25'
+      target:
+        type: string
+        required: true
+    outputs:
+      artifact:
+        description: Name of the uploaded artifact
+        value: ${{ jobs.build.outputs.artifact }}
+      # @output.digest: SHA-256 of the binary
+      digest:
+        value: ${{ jobs.build.outputs.digest }}
+    secrets:
+      registry-token:
+        description: Token for the container registry
+        required: true
+      slack-webhook:
+  workflow_dispatch:
+    inputs:
+      debug:
+        type: boolean
+# @secret.slack-webhook: Optional notification hook
+`
+	filePath := filepath.Join(tempDir, "build.yml")
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	doc, err := ParseWorkflowFile(filePath)
+	if err != nil {
+		t.Fatalf("ParseWorkflowFile failed: %v", err)
+	}
+
+	if !doc.IsReusable() {
+		t.Error("Expected workflow with workflow_call to be reusable")
+	}
+
+	if len(doc.Outputs) != 2 {
+		t.Fatalf("Expected 2 outputs, got %d", len(doc.Outputs))
+	}
+	if doc.Outputs[0].Name != "artifact" || doc.Outputs[0].Description != "Name of the uploaded artifact" {
+		t.Errorf("Unexpected first output: %+v", doc.Outputs[0])
+	}
+	if doc.Outputs[0].Value != "${{ jobs.build.outputs.artifact }}" {
+		t.Errorf("Expected output value expression, got '%s'", doc.Outputs[0].Value)
+	}
+	if doc.Outputs[1].Description != "SHA-256 of the binary" {
+		t.Errorf("Expected annotated output description, got '%s'", doc.Outputs[1].Description)
+	}
+
+	if len(doc.Secrets) != 2 {
+		t.Fatalf("Expected 2 secrets, got %d", len(doc.Secrets))
+	}
+	if !doc.Secrets[0].Required || doc.Secrets[0].Description != "Token for the container registry" {
+		t.Errorf("Unexpected first secret: %+v", doc.Secrets[0])
+	}
+	if doc.Secrets[1].Required || doc.Secrets[1].Description != "Optional notification hook" {
+		t.Errorf("Unexpected second secret: %+v", doc.Secrets[1])
+	}
+
+	snippet := doc.UsageSnippet()
+	expected := `jobs:
+  build:
+    uses: <owner>/<repo>/.github/workflows/build.yml@<ref>
+    with:
+      go-version: 1.25 # optional, string
+      target: "" # required, string
+    secrets:
+      registry-token: ${{ secrets.registry-token }} # required
+      slack-webhook: ${{ secrets.slack-webhook }} # optional
+`
+	if snippet != expected {
+		t.Errorf("Unexpected usage snippet:\n%s\nwant:\n%s", snippet, expected)
+	}
+	if strings.Contains(snippet, "debug") {
+		t.Error("Usage snippet should only contain workflow_call inputs")
+	}
+}
+
+func TestUsageJobID(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"build", "build"},
+		{"deploy.prod", "deploy-prod"},
+		{"1-build", "_1-build"},
+		{"_private", "_private"},
+		{"-lint", "_-lint"},
+		{"résumé", "r--sum--"},
+		{"", "_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := usageJobID(tt.name); got != tt.want {
+				t.Errorf("Expected '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+
+	doc := &WorkflowDoc{FileName: "deploy.prod.yml"}
+	if snippet := doc.UsageSnippet(); !strings.HasPrefix(snippet, "jobs:\n  deploy-prod:\n") {
+		t.Errorf("Expected job ID deploy-prod, got:\n%s", snippet)
+	}
+}
+
+func TestUsageValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"1.22", "1.22"},
+		{"", `""`},
+		{"a\nb", `"a\nb"`},
+		{" padded ", `" padded "`},
+		{"key: value", `"key: value"`},
+		{"${{ github.ref }}", `"${{ github.ref }}"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got := usageValue(tt.value)
+			if got != tt.want {
+				t.Errorf("Expected '%s', got '%s'", tt.want, got)
+			}
+
+			// The snippet line must read back as the original value
+			var decoded map[string]string
+			if err := yaml.Unmarshal([]byte("v: "+got+" # optional\n"), &decoded); err != nil {
+				t.Fatalf("Failed to decode '%s': %v", got, err)
+			}
+			if decoded["v"] != tt.value {
+				t.Errorf("Expected '%s' to decode to %q, got %q", got, tt.value, decoded["v"])
+			}
+		})
+	}
+}
+
+func TestIsReusable(t *testing.T) {
+	doc := &WorkflowDoc{Triggers: []Trigger{{Event: "push"}}}
+	if doc.IsReusable() {
+		t.Error("Expected workflow without workflow_call not to be reusable")
+	}
+}
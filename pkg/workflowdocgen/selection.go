@@ -0,0 +1,102 @@
+package workflowdocgen
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Sort orders of SortWorkflows
+const (
+	SortByFile = "file"
+	SortByName = "name"
+)
+
+// SortOrders lists the supported sort orders
+var SortOrders = []string{SortByFile, SortByName}
+
+// selectPath reports whether a file, given by its slash-separated path
+// relative to the workflows directory, matches one of the include globs, or
+// any file if there are none, and none of the exclude globs
+func selectPath(rel string, include, exclude []string) bool {
+	if len(include) > 0 && !matchAny(include, rel) {
+		return false
+	}
+	return !matchAny(exclude, rel)
+}
+
+// matchAny reports whether rel matches one of the glob patterns, see matchGlob
+func matchAny(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(pattern, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob reports whether a slash-separated relative path matches pattern.
+// A pattern without a slash matches the file name in any directory; otherwise
+// it matches the whole path, and a ** segment matches any number of
+// directories. Invalid patterns never match.
+func matchGlob(pattern, rel string) bool {
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(rel))
+		return matched
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(rel, "/"))
+}
+
+// matchSegments implements matchGlob for patterns with a slash
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// SortWorkflows sorts workflows in place by file path or by display name.
+// Workflows with the same name are ordered by file path.
+func SortWorkflows(docs []*WorkflowDoc, order string) error {
+	byPath := func(a, b *WorkflowDoc) int {
+		return cmp.Compare(filepath.ToSlash(a.FilePath), filepath.ToSlash(b.FilePath))
+	}
+
+	switch order {
+	case "", SortByFile:
+		slices.SortStableFunc(docs, byPath)
+	case SortByName:
+		slices.SortStableFunc(docs, func(a, b *WorkflowDoc) int {
+			return cmp.Or(cmp.Compare(sortName(a), sortName(b)), byPath(a, b))
+		})
+	default:
+		return fmt.Errorf("unknown sort order %q, expected one of: %s", order, strings.Join(SortOrders, ", "))
+	}
+	return nil
+}
+
+// sortName is the case-insensitive name a workflow is sorted by
+func sortName(doc *WorkflowDoc) string {
+	name := doc.DisplayName()
+	if name == "" {
+		name = doc.FileName
+	}
+	return strings.ToLower(name)
+}
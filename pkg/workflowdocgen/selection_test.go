@@ -0,0 +1,84 @@
+package workflowdocgen
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSelectPath(t *testing.T) {
+	tests := []struct {
+		name             string
+		rel              string
+		include, exclude []string
+		want             bool
+	}{
+		{"no globs", "ci.yml", nil, nil, true},
+		{"file name include", "ci.yml", []string{"*.yml"}, nil, true},
+		{"file name include mismatch", "release.yaml", []string{"*.yml"}, nil, false},
+		{"file name matches in subdirectory", "templates/deploy.yml", []string{"deploy.yml"}, nil, true},
+		{"file name exclude", "experimental-build.yml", nil, []string{"experimental-*"}, false},
+		{"include and exclude", "experimental-build.yml", []string{"*.yml"}, []string{"experimental-*"}, false},
+		{"path", "templates/deploy.yml", []string{"templates/*.yml"}, nil, true},
+		{"path is anchored", "other/templates/deploy.yml", []string{"templates/*.yml"}, nil, false},
+		{"double star", "templates/go/lib/build.yml", []string{"templates/**/*.yml"}, nil, true},
+		{"double star matches no directory", "templates/build.yml", []string{"templates/**/*.yml"}, nil, true},
+		{"leading double star", "a/b/actions/setup.yml", nil, []string{"**/actions/*"}, false},
+		{"star does not cross directories", "templates/go/build.yml", []string{"templates/*.yml"}, nil, false},
+		{"invalid pattern never matches", "ci.yml", []string{"["}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectPath(tt.rel, tt.include, tt.exclude); got != tt.want {
+				t.Errorf("Expected selectPath(%q) to be %v, got %v", tt.rel, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSortWorkflows(t *testing.T) {
+	newDocs := func() []*WorkflowDoc {
+		return []*WorkflowDoc{
+			{Name: "Zeta", FileName: "a.yml", FilePath: "wf/a.yml"},
+			{FileName: "c.yml", FilePath: "wf/c.yml"},
+			{Name: "alpha", FileName: "b.yml", FilePath: "wf/b.yml"},
+			{Name: "Alpha", FileName: "a.yml", FilePath: "other/a.yml"},
+		}
+	}
+
+	t.Run("by file", func(t *testing.T) {
+		docs := newDocs()
+		if err := SortWorkflows(docs, SortByFile); err != nil {
+			t.Fatalf("SortWorkflows failed: %v", err)
+		}
+		want := []string{"other/a.yml", "wf/a.yml", "wf/b.yml", "wf/c.yml"}
+		var got []string
+		for _, doc := range docs {
+			got = append(got, doc.FilePath)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("by name", func(t *testing.T) {
+		docs := newDocs()
+		if err := SortWorkflows(docs, SortByName); err != nil {
+			t.Fatalf("SortWorkflows failed: %v", err)
+		}
+		want := []string{"other/a.yml", "wf/b.yml", "wf/c.yml", "wf/a.yml"}
+		var got []string
+		for _, doc := range docs {
+			got = append(got, doc.FilePath)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("unknown order", func(t *testing.T) {
+		if err := SortWorkflows(newDocs(), "date"); err == nil {
+			t.Error("Expected error for unknown sort order, got nil")
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package workflowdocgen
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// workflowLayout records where jobs and steps are declared in the YAML source
+// so that annotation comments can be attached to them by position
+type workflowLayout struct {
+	jobsLine int
+	jobs     []*jobPosition
+}
+
+// jobPosition records the position of a job key and its step items
+type jobPosition struct {
+	job        *JobDoc
+	line       int
+	column     int
+	stepColumn int
+	steps      []stepPosition
+}
+
+// stepPosition records the line of a step list item
+type stepPosition struct {
+	step *StepDoc
+	line int
+}
+
+// parseWorkflowYAML decodes the workflow definition and populates doc from it
+func parseWorkflowYAML(doc *WorkflowDoc, content []byte) (*workflowLayout, error) {
+	layout := &workflowLayout{}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return layout, err
+	}
+
+	root := documentRoot(&document)
+	if root == nil {
+		return layout, nil
+	}
+
+	doc.DeclaredName = scalarValue(mappingValue(root, "name"))
+	doc.DeclaredPermissions = parsePermissions(mappingValue(root, "permissions"), PermissionSourceWorkflow)
+
+	if on := mappingValue(root, "on"); on != nil {
+		doc.Triggers = parseTriggers(on)
+		doc.Inputs = parseInputs(on)
+		doc.Outputs = parseOutputs(on)
+		doc.Secrets = parseSecrets(on)
+	}
+
+	jobsKey, jobs := mappingEntry(root, "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return layout, nil
+	}
+	layout.jobsLine = jobsKey.Line
+
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		key, value := jobs.Content[i], jobs.Content[i+1]
+		position := &jobPosition{
+			job:    parseJob(key.Value, value),
+			line:   key.Line,
+			column: key.Column,
+		}
+
+		if steps := mappingValue(value, "steps"); steps != nil && steps.Kind == yaml.SequenceNode {
+			position.stepColumn = steps.Column
+			for index, item := range steps.Content {
+				step := parseStep(index+1, item)
+				position.job.Steps = append(position.job.Steps, step)
+				position.steps = append(position.steps, stepPosition{step: step, line: item.Line})
+			}
+		}
+
+		doc.Jobs = append(doc.Jobs, position.job)
+		layout.jobs = append(layout.jobs, position)
+	}
+
+	return layout, nil
+}
+
+// parseJob reads the structural fields of a single job
+func parseJob(id string, node *yaml.Node) *JobDoc {
+	job := &JobDoc{
+		ID:     id,
+		Name:   scalarValue(mappingValue(node, "name")),
+		RunsOn: strings.Join(scalarList(mappingValue(node, "runs-on")), ", "),
+		Needs:  scalarList(mappingValue(node, "needs")),
+		If:     scalarValue(mappingValue(node, "if")),
+		Uses:   scalarValue(mappingValue(node, "uses")),
+		Matrix: mappingValue(mappingValue(node, "strategy"), "matrix") != nil,
+
+		DeclaredPermissions: parsePermissions(mappingValue(node, "permissions"), PermissionSourceJob),
+	}
+
+	if ref, ok := parseWorkflowRef(job.Uses); ok {
+		uses := mappingValue(node, "uses")
+		ref.Position = Position{Line: uses.Line, Column: uses.Column}
+		job.Call = &ref
+	}
+
+	return job
+}
+
+// parseStep reads the structural fields of a single step
+func parseStep(index int, node *yaml.Node) *StepDoc {
+	return &StepDoc{
+		Index: index,
+		ID:    scalarValue(mappingValue(node, "id")),
+		Name:  scalarValue(mappingValue(node, "name")),
+		Uses:  scalarValue(mappingValue(node, "uses")),
+	}
+}
+
+// documentRoot returns the top-level mapping of a YAML document, if any
+func documentRoot(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	return node
+}
+
+// mappingEntry returns the key and value nodes for key in a mapping node
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// mappingValue returns the value node for key in a mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	_, value := mappingEntry(node, key)
+	return value
+}
+
+// scalarValue returns the value of a scalar node, or "" for other nodes
+func scalarValue(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
+
+// scalarList returns a scalar as a one-element list, or the scalar items of a sequence
+func scalarList(node *yaml.Node) []string {
+	if node == nil {
+		return nil
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Value == "" {
+			return nil
+		}
+		return []string{node.Value}
+	case yaml.SequenceNode:
+		var values []string
+		for _, item := range node.Content {
+			if item.Kind == yaml.ScalarNode {
+				values = append(values, item.Value)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package workflowdocgen
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Trigger represents an event of the workflow's on: block.
+// Every cron expression of a schedule event is a separate Trigger.
+type Trigger struct {
+	Event          string
+	Types          []string
+	Branches       []string
+	BranchesIgnore []string
+	Tags           []string
+	TagsIgnore     []string
+	Paths          []string
+	PathsIgnore    []string
+	Workflows      []string
+	Cron           string
+}
+
+// parseTriggers reads the events of an on: block in declaration order
+func parseTriggers(on *yaml.Node) []Trigger {
+	var triggers []Trigger
+	if on.Kind != yaml.MappingNode {
+		for _, event := range scalarList(on) {
+			triggers = append(triggers, Trigger{Event: event})
+		}
+		return triggers
+	}
+
+	for i := 0; i+1 < len(on.Content); i += 2 {
+		event, config := on.Content[i].Value, on.Content[i+1]
+
+		if event == "schedule" && config.Kind == yaml.SequenceNode {
+			for _, item := range config.Content {
+				triggers = append(triggers, Trigger{
+					Event: event,
+					Cron:  scalarValue(mappingValue(item, "cron")),
+				})
+			}
+			continue
+		}
+
+		triggers = append(triggers, Trigger{
+			Event:          event,
+			Types:          scalarList(mappingValue(config, "types")),
+			Branches:       scalarList(mappingValue(config, "branches")),
+			BranchesIgnore: scalarList(mappingValue(config, "branches-ignore")),
+			Tags:           scalarList(mappingValue(config, "tags")),
+			TagsIgnore:     scalarList(mappingValue(config, "tags-ignore")),
+			Paths:          scalarList(mappingValue(config, "paths")),
+			PathsIgnore:    scalarList(mappingValue(config, "paths-ignore")),
+			Workflows:      scalarList(mappingValue(config, "workflows")),
+		})
+	}
+	return triggers
+}
+
+// Describe returns a human-readable description of the trigger's filters
+func (t Trigger) Describe() string {
+	var parts []string
+
+	switch t.Event {
+	case "schedule":
+		if description, err := DescribeCron(t.Cron); err == nil {
+			parts = append(parts, fmt.Sprintf("%s (`%s`)", description, t.Cron))
+		} else if t.Cron != "" {
+			parts = append(parts, fmt.Sprintf("`%s`", t.Cron))
+		}
+	case "workflow_dispatch":
+		parts = append(parts, "manual run")
+	case "workflow_call":
+		parts = append(parts, "called by other workflows")
+	}
+
+	filters := []struct {
+		label  string
+		values []string
+	}{
+		{"workflows", t.Workflows},
+		{"types", t.Types},
+		{"branches", t.Branches},
+		{"ignored branches", t.BranchesIgnore},
+		{"tags", t.Tags},
+		{"ignored tags", t.TagsIgnore},
+		{"paths", t.Paths},
+		{"ignored paths", t.PathsIgnore},
+	}
+	for _, filter := range filters {
+		if len(filter.values) > 0 {
+			parts = append(parts, fmt.Sprintf("%s: `%s`", filter.label, strings.Join(filter.values, "`, `")))
+		}
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// TriggerEvents returns the distinct event names of the workflow in declaration order
+func (d *WorkflowDoc) TriggerEvents() []string {
+	var events []string
+	seen := make(map[string]bool)
+	for _, trigger := range d.Triggers {
+		if !seen[trigger.Event] {
+			seen[trigger.Event] = true
+			events = append(events, trigger.Event)
+		}
+	}
+	return events
+}
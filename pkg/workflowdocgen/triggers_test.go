@@ -0,0 +1,86 @@
+package workflowdocgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseTriggers(t *testing.T) {
+	tempDir := t.TempDir()
+
+	content := `# @workflow.triggers: pushes, PRs, nightly
+name: Triggers
+on:
+  push:
+    branches: [main, 'release/**']
+    tags: ['v*']
+    paths-ignore:
+      - 'docs/**'
+  pull_request:
+    types: [opened, synchronize]
+    branches-ignore: [wip]
+  schedule:
+    - cron: '0 3 * * *'
+    - cron: '*/30 * * * *'
+  workflow_dispatch:
+  repository_dispatch:
+    types: [deploy]
+  workflow_run:
+    workflows: [CI]
+    types: [completed]
+`
+	filePath := filepath.Join(tempDir, "triggers.yml")
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil { // #nosec G306 - test file
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	doc, err := ParseWorkflowFile(filePath)
+	if err != nil {
+		t.Fatalf("ParseWorkflowFile failed: %v", err)
+	}
+
+	if doc.TriggersNote != "pushes, PRs, nightly" {
+		t.Errorf("Expected triggers note 'pushes, PRs, nightly', got '%s'", doc.TriggersNote)
+	}
+
+	if len(doc.Triggers) != 7 {
+		t.Fatalf("Expected 7 triggers, got %d: %+v", len(doc.Triggers), doc.Triggers)
+	}
+
+	events := doc.TriggerEvents()
+	wantEvents := []string{"push", "pull_request", "schedule", "workflow_dispatch", "repository_dispatch", "workflow_run"}
+	if len(events) != len(wantEvents) {
+		t.Fatalf("Expected events %v, got %v", wantEvents, events)
+	}
+	for i, event := range wantEvents {
+		if events[i] != event {
+			t.Errorf("Expected event %d to be '%s', got '%s'", i, event, events[i])
+		}
+	}
+
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{0, "branches: `main`, `release/**`; tags: `v*`; ignored paths: `docs/**`"},
+		{1, "types: `opened`, `synchronize`; ignored branches: `wip`"},
+		{2, "At 03:00 UTC every day (`0 3 * * *`)"},
+		{3, "Every 30 minutes (`*/30 * * * *`)"},
+		{4, "manual run"},
+		{5, "types: `deploy`"},
+		{6, "workflows: `CI`; types: `completed`"},
+	}
+	for _, tt := range tests {
+		if got := doc.Triggers[tt.index].Describe(); got != tt.want {
+			t.Errorf("Trigger %d (%s): expected description %q, got %q", tt.index, doc.Triggers[tt.index].Event, tt.want, got)
+		}
+	}
+}
+
+func TestTriggerDescribeInvalidCron(t *testing.T) {
+	trigger := Trigger{Event: "schedule", Cron: "not a cron"}
+	if got := trigger.Describe(); got != "`not a cron`" {
+		t.Errorf("Expected raw cron for invalid expression, got %q", got)
+	}
+}